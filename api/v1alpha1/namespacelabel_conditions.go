@@ -0,0 +1,154 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// ConditionType is the type of a condition reported in NamespacelabelStatus.Conditions.
+type ConditionType string
+
+// ConditionReason is the reason of a condition reported in NamespacelabelStatus.Conditions.
+type ConditionReason string
+
+// Condition types reported by the Namespacelabel controller.
+const (
+	// ConditionLabelsApplied reports whether the desired labels were reconciled onto the namespace.
+	ConditionLabelsApplied ConditionType = "LabelsApplied"
+	// ConditionLabelsSkipped reports whether some labels were skipped because they are protected.
+	ConditionLabelsSkipped ConditionType = "LabelsSkipped"
+	// ConditionDuplicateLabels reports whether some labels were not applied because the namespace already has them.
+	ConditionDuplicateLabels ConditionType = "DuplicateLabels"
+	// ConditionPartiallyApplied reports whether the written labels differ from what landed on the namespace.
+	ConditionPartiallyApplied ConditionType = "PartiallyApplied"
+	// ConditionStrictProtectedViolation reports whether a strictProtected CR asked for protected labels.
+	ConditionStrictProtectedViolation ConditionType = "StrictProtectedViolation"
+	// ConditionSuspiciousEmptySpec reports whether an unconfirmed transition to an empty spec was held back.
+	ConditionSuspiciousEmptySpec ConditionType = "SuspiciousEmptySpec"
+	// ConditionScheduled reports whether applying labels is delayed until spec.applyAfter.
+	ConditionScheduled ConditionType = "Scheduled"
+	// ConditionTargetResolved reports whether spec.namespaceLabelSelector resolved to exactly one namespace.
+	ConditionTargetResolved ConditionType = "TargetResolved"
+	// ConditionOptedOut reports whether the target namespace opted out of operator management.
+	ConditionOptedOut ConditionType = "OptedOut"
+	// ConditionConfigLoaded reports whether the protected labels configuration loaded on the last reconcile.
+	ConditionConfigLoaded ConditionType = "ConfigLoaded"
+	// ConditionOutsideWindow reports whether applying labels is deferred until spec.maintenanceWindow opens.
+	ConditionOutsideWindow ConditionType = "OutsideWindow"
+	// ConditionNamespaceDenied reports whether the target namespace is listed in the operator's deny ConfigMap.
+	ConditionNamespaceDenied ConditionType = "NamespaceDenied"
+	// ConditionReconcileError reports whether the last reconcile failed, with the error as its message.
+	ConditionReconcileError ConditionType = "ReconcileError"
+	// ConditionNoPermission reports whether the operator lacks permission to update the target namespace.
+	ConditionNoPermission ConditionType = "NoPermission"
+	// ConditionDependencyMissing reports whether the resource named in spec.requires is missing.
+	ConditionDependencyMissing ConditionType = "DependencyMissing"
+	// ConditionProtectedOverrideUsed reports whether protected labels were applied because the CR is exempt from
+	// protected labels, listing the keys in its message.
+	ConditionProtectedOverrideUsed ConditionType = "ProtectedOverrideUsed"
+	// ConditionObserving reports whether the Namespacelabel only observes its target namespace under spec.observeOnly.
+	ConditionObserving ConditionType = "Observing"
+	// ConditionDeprecatedLabels reports whether the desired labels use keys the operator lists as deprecated.
+	ConditionDeprecatedLabels ConditionType = "DeprecatedLabels"
+)
+
+// KnownConditionTypes lists every condition type the controller currently reports.
+// Conditions of any other type are left over from removed features and are pruned from status.
+var KnownConditionTypes = []ConditionType{
+	ConditionLabelsApplied,
+	ConditionLabelsSkipped,
+	ConditionDuplicateLabels,
+	ConditionPartiallyApplied,
+	ConditionStrictProtectedViolation,
+	ConditionSuspiciousEmptySpec,
+	ConditionScheduled,
+	ConditionTargetResolved,
+	ConditionOptedOut,
+	ConditionConfigLoaded,
+	ConditionOutsideWindow,
+	ConditionNamespaceDenied,
+	ConditionReconcileError,
+	ConditionNoPermission,
+	ConditionDependencyMissing,
+	ConditionProtectedOverrideUsed,
+	ConditionObserving,
+	ConditionDeprecatedLabels,
+}
+
+// Condition reasons reported by the Namespacelabel controller.
+const (
+	ReasonLabelsReconciled           ConditionReason = "LabelsReconciled"
+	ReasonProtectedLabelsHandled     ConditionReason = "ProtectedLabelsHandled"
+	ReasonDuplicateLabelsHandled     ConditionReason = "DuplicateLabelsHandled"
+	ReasonApplyFailed                ConditionReason = "ApplyFailed"
+	ReasonLabelsNotPresent           ConditionReason = "LabelsNotPresent"
+	ReasonAllLabelsPresent           ConditionReason = "AllLabelsPresent"
+	ReasonProtectedLabelsRequested   ConditionReason = "ProtectedLabelsRequested"
+	ReasonNoProtectedLabelsRequested ConditionReason = "NoProtectedLabelsRequested"
+	ReasonStrictProtectedViolation   ConditionReason = "StrictProtectedViolation"
+	ReasonEmptySpecNotConfirmed      ConditionReason = "EmptySpecNotConfirmed"
+	ReasonApplyScheduled             ConditionReason = "ApplyScheduled"
+	ReasonApplyTimeReached           ConditionReason = "ApplyTimeReached"
+	ReasonTargetResolved             ConditionReason = "TargetResolved"
+	ReasonNoMatchingNamespace        ConditionReason = "NoMatchingNamespace"
+	ReasonAmbiguousTarget            ConditionReason = "AmbiguousTarget"
+	ReasonNamespaceOptedOut          ConditionReason = "NamespaceOptedOut"
+	ReasonProtectedConfigLoaded      ConditionReason = "ProtectedConfigLoaded"
+	ReasonProtectedConfigMissing     ConditionReason = "ProtectedConfigMissing"
+	ReasonProtectedConfigInvalid     ConditionReason = "ProtectedConfigInvalid"
+	ReasonOutsideMaintenanceWindow   ConditionReason = "OutsideMaintenanceWindow"
+	ReasonInsideMaintenanceWindow    ConditionReason = "InsideMaintenanceWindow"
+	ReasonNamespaceDenied            ConditionReason = "NamespaceDenied"
+	ReasonReconcileFailed            ConditionReason = "ReconcileFailed"
+	ReasonReconcileSucceeded         ConditionReason = "ReconcileSucceeded"
+	ReasonNamespaceUpdateForbidden   ConditionReason = "NamespaceUpdateForbidden"
+	ReasonDependencyNotFound         ConditionReason = "DependencyNotFound"
+	ReasonDependencyFound            ConditionReason = "DependencyFound"
+	ReasonProtectedLabelsOverridden  ConditionReason = "ProtectedLabelsOverridden"
+	ReasonObserveOnly                ConditionReason = "ObserveOnly"
+	ReasonDeprecatedKeysUsed         ConditionReason = "DeprecatedKeysUsed"
+	ReasonRetriesExhausted           ConditionReason = "RetriesExhausted"
+	ReasonMissingNamespace           ConditionReason = "MissingNamespace"
+	ReasonCleanApply                 ConditionReason = "CleanApply"
+)
+
+// SkipReason explains why a desired label was recorded in NamespacelabelStatus.SkippedLabels.
+type SkipReason string
+
+// Reasons a desired label can be skipped.
+const (
+	// SkipReasonProtected means the key is protected by the operator configuration.
+	SkipReasonProtected SkipReason = "Protected"
+	// SkipReasonNamespaceExcluded means the namespace owner excluded the key with the labels.dana.io/exclude annotation.
+	SkipReasonNamespaceExcluded SkipReason = "NamespaceExcluded"
+	// SkipReasonSizeBudgetExceeded means applying the key would exceed the operator's total label size budget.
+	SkipReasonSizeBudgetExceeded SkipReason = "SizeBudgetExceeded"
+	// SkipReasonValueTooLongAfterTransform means the key's spec.transforms entry produced a value longer than
+	// a label value may be.
+	SkipReasonValueTooLongAfterTransform SkipReason = "ValueTooLongAfterTransform"
+	// SkipReasonShadowsSystemLabel means the key is a namespace label maintained by Kubernetes itself,
+	// such as kubernetes.io/metadata.name.
+	SkipReasonShadowsSystemLabel SkipReason = "ShadowsSystemLabel"
+	// SkipReasonReservedPrefix means the key uses the labels.dana.io/ prefix reserved for the operator's own labels.
+	// The webhook rejects such keys in the spec; this covers keys coming from spec.labelsFrom or spec.teamRef.
+	SkipReasonReservedPrefix SkipReason = "ReservedPrefix"
+	// SkipReasonRenderFailed means the key's spec.labels template could not be rendered, or rendered to a value
+	// that is not a valid label value.
+	SkipReasonRenderFailed SkipReason = "RenderFailed"
+	// SkipReasonValuePatternMismatch means the key's value does not match its spec.valuePatterns entry,
+	// or the entry is not a valid regular expression.
+	SkipReasonValuePatternMismatch SkipReason = "ValuePatternMismatch"
+	// SkipReasonValueNotAllowed means the key's value is not one of the values listed in its spec.valueEnums entry.
+	SkipReasonValueNotAllowed SkipReason = "ValueNotAllowed"
+)
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // NamespacelabelSpec defines the desired state of Namespacelabel
@@ -25,6 +26,203 @@ type NamespacelabelSpec struct {
 	// Labels is a map of key-value pairs that should be applied to the target namespace.
 	// The keys are the label names, and the values are the corresponding label values.
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations is a map of key-value pairs that should be applied to the target namespace as annotations.
+	// They are handled like Labels: keys protected by the operator's PROTECTED_ANNOTATIONS are skipped, keys the
+	// namespace already carries from someone else are left alone, and the applied annotations are removed on deletion.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// EnableTemplating allows label values to contain template syntax such as `{{ .Vars.region }}`.
+	// When it is false, values that look like templates are rejected at admission time.
+	EnableTemplating bool `json:"enableTemplating,omitempty"`
+
+	// PropagateTo optionally lists resource types whose objects in the namespace also receive the managed labels.
+	// Entries have the form "<version>/<resource>" for the core group or "<group>/<version>/<resource>",
+	// for example "v1/configmaps" or "apps/v1/deployments". Only configmaps, serviceaccounts, services, and the apps
+	// daemonsets, deployments and statefulsets are supported, since those are what the operator may patch.
+	PropagateTo []string `json:"propagateTo,omitempty"`
+
+	// RecordApplyTimestamp makes the operator annotate the namespace with the time of the last successful apply that
+	// changed it, using the labels.dana.io/last-applied annotation in RFC3339 format.
+	RecordApplyTimestamp bool `json:"recordApplyTimestamp,omitempty"`
+
+	// RecordSpecHash makes the operator label the namespace with labels.dana.io/spec-hash-<id>, a short hash of the
+	// applied labels, so external tools can detect changes by watching a single label. Each Namespacelabel has its
+	// own label: <id> is the first 10 hex characters of the SHA-256 of "<namespace>/<name>" of the Namespacelabel.
+	RecordSpecHash bool `json:"recordSpecHash,omitempty"`
+
+	// MirrorToAnnotations makes the operator also write each applied label as a namespace annotation
+	// labels.dana.io/applied.<key>, for tools that read annotations rather than labels.
+	// A "/" in the label key is written as "_" in the annotation key. Annotation keys that would run past the
+	// 63 character name limit are cut short and end with a hash of the label key.
+	MirrorToAnnotations bool `json:"mirrorToAnnotations,omitempty"`
+
+	// VerboseEvents makes the AppliedLabels event list every key=value applied, in application order.
+	// Otherwise the event only counts the applied labels.
+	VerboseEvents bool `json:"verboseEvents,omitempty"`
+
+	// NotifyOnChangeOnly limits webhook notifications to effective changes: an apply is only notified when a label
+	// was added or changed its value, and a cleanup only when labels were applied. Writes that only refresh the
+	// operator's own annotations and labels, such as the apply timestamp, are not notified.
+	NotifyOnChangeOnly bool `json:"notifyOnChangeOnly,omitempty"`
+
+	// LabelOrder optionally lists label keys to apply first, in the given order.
+	// Keys not listed are applied afterwards in lexical order. The order is reflected in events and logs.
+	LabelOrder []string `json:"labelOrder,omitempty"`
+
+	// StrictProtected makes the apply all-or-nothing with respect to protected labels.
+	// When any desired label is protected, no label is applied and the StrictProtectedViolation condition is set.
+	StrictProtected bool `json:"strictProtected,omitempty"`
+
+	// TeamRef optionally names a team object that owns this Namespacelabel, so it is garbage-collected when the team
+	// is deleted. The team must live in the same namespace or be cluster-scoped.
+	TeamRef *TeamReference `json:"teamRef,omitempty"`
+
+	// ApplyAfter optionally delays applying labels until the given time, for staged rollouts.
+	// Until then the Scheduled condition is set and nothing is applied. A time in the past applies immediately.
+	ApplyAfter *metav1.Time `json:"applyAfter,omitempty"`
+
+	// NamespaceName optionally names the namespace to label instead of the Namespacelabel's own namespace.
+	NamespaceName string `json:"namespaceName,omitempty"`
+
+	// NamespaceLabelSelector optionally selects the namespace to label by its labels.
+	// Exactly one namespace must match; otherwise nothing is applied and the TargetResolved condition is False.
+	// It cannot be combined with NamespaceName.
+	NamespaceLabelSelector *metav1.LabelSelector `json:"namespaceLabelSelector,omitempty"`
+
+	// ImmutableKeys lists keys of spec.labels whose values cannot change once set.
+	// Updates changing them are rejected at admission, and if anyone changes them on the namespace
+	// the operator reverts them to the value in spec.labels and emits an ImmutableReverted event.
+	// Only keys this Namespacelabel applied are reverted; a key the namespace already had from someone else is
+	// handled like any other existing label.
+	ImmutableKeys []string `json:"immutableKeys,omitempty"`
+
+	// LabelsFrom optionally names a ConfigMap in the Namespacelabel's namespace whose data is merged into the
+	// desired labels. Keys set in spec.labels take precedence over the same keys in the ConfigMap.
+	LabelsFrom *ConfigMapReference `json:"labelsFrom,omitempty"`
+
+	// DryRun makes the operator compute what it would apply without writing to the namespace.
+	// The computed plan is written as JSON to the labels.dana.io/dry-run-plan annotation on the Namespacelabel.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Aliases maps a desired label key to additional keys that receive the same value.
+	// Each alias is handled like its own desired label, so protected and duplicate checks apply to it separately.
+	// An alias never overrides a key that is desired directly.
+	Aliases map[string][]string `json:"aliases,omitempty"`
+
+	// Transforms maps a label key to a transform applied to its value before it is written to the namespace.
+	// Supported transforms are upper, lower, trim and slug. Aliases are transformed under their own key.
+	Transforms map[string]LabelTransform `json:"transforms,omitempty"`
+
+	// ValuePatterns maps a label key to a regular expression its value must match in full, after any transform.
+	// A key whose value does not match is skipped with reason ValuePatternMismatch.
+	ValuePatterns map[string]string `json:"valuePatterns,omitempty"`
+
+	// ValueEnums maps a label key to the values it may take, after any transform. A key whose value is not listed is
+	// skipped with reason ValueNotAllowed, or rejected at admission time when the operator's VALUE_ENUM_MODE is reject.
+	ValueEnums map[string][]string `json:"valueEnums,omitempty"`
+
+	// MaintenanceWindow restricts applying labels to a recurring time window. Outside the window reconciles
+	// defer the apply until it opens. Cleanup on deletion is not restricted.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Requires optionally names a resource that must exist before labels are applied. While it is missing the
+	// DependencyMissing condition is set and the apply is retried periodically.
+	// The user creating or updating the Namespacelabel must be allowed to get the resource.
+	Requires *ResourceReference `json:"requires,omitempty"`
+
+	// ObserveOnly makes the Namespacelabel a read-only view of its target namespace: nothing is applied or
+	// cleaned up, and the namespace's current labels are reported in status.observedLabels instead.
+	// Labels applied before switching to observe-only are left in place.
+	ObserveOnly bool `json:"observeOnly,omitempty"`
+
+	// OverwritePolicy decides what happens to a desired label whose key the namespace already carries from someone
+	// else. With Skip, the default, the label is reported as a duplicate and never applied. With Overwrite, the
+	// namespace's value is replaced and the key is owned by this Namespacelabel from then on. Protected labels are
+	// skipped either way. It only applies to labels; spec.annotations keeps skipping duplicates.
+	OverwritePolicy OverwritePolicy `json:"overwritePolicy,omitempty"`
+
+	// RedactStatusValues keeps label values out of the status and events, for namespaces whose label values are
+	// sensitive. Status.appliedLabels, status.skippedLabels and status.history record each key with the value
+	// "<redacted>", and events name keys without their values.
+	RedactStatusValues bool `json:"redactStatusValues,omitempty"`
+}
+
+// ResourceReference identifies a resource by kind and name.
+type ResourceReference struct {
+	// APIVersion is the API version of the resource, for example "v1" or "apps/v1".
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the resource, for example "ConfigMap".
+	Kind string `json:"kind"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the resource. Defaults to the Namespacelabel's namespace and is ignored for
+	// cluster-scoped kinds.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// MaintenanceWindow is a recurring daily time window.
+type MaintenanceWindow struct {
+	// Start is the time of day the window opens, as "HH:MM".
+	Start string `json:"start"`
+	// End is the time of day the window closes, as "HH:MM". An End before Start makes the window run past midnight.
+	End string `json:"end"`
+	// Days lists the days the window opens on, as "Mon" through "Sun". Empty means every day.
+	Days []string `json:"days,omitempty"`
+	// TimeZone is the IANA time zone Start and End are given in. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// LabelTransform names a transform applied to a label value.
+type LabelTransform string
+
+// Supported label value transforms.
+const (
+	// TransformUpper upper-cases the value.
+	TransformUpper LabelTransform = "upper"
+	// TransformLower lower-cases the value.
+	TransformLower LabelTransform = "lower"
+	// TransformTrim removes leading and trailing whitespace.
+	TransformTrim LabelTransform = "trim"
+	// TransformSlug lower-cases the value, replaces every run of characters other than letters and digits with "-",
+	// and trims leading and trailing dashes.
+	TransformSlug LabelTransform = "slug"
+)
+
+// OverwritePolicy names how a Namespacelabel handles labels the namespace already carries.
+type OverwritePolicy string
+
+// Supported overwrite policies.
+const (
+	// OverwritePolicySkip leaves existing labels alone and reports them as duplicates.
+	OverwritePolicySkip OverwritePolicy = "Skip"
+	// OverwritePolicyOverwrite replaces the values of existing labels.
+	OverwritePolicyOverwrite OverwritePolicy = "Overwrite"
+)
+
+// ConfigMapReference names a ConfigMap in the Namespacelabel's namespace.
+type ConfigMapReference struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+}
+
+// TeamReference identifies a team object, such as one defined by an organisation's Team CRD.
+type TeamReference struct {
+	// APIVersion is the API version of the team, for example "teams.example.com/v1".
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the team, for example "Team".
+	Kind string `json:"kind"`
+
+	// Name is the name of the team.
+	Name string `json:"name"`
+
+	// LabelKey optionally names a label applied to the namespace with the team's name as its value.
+	// It is handled like any key in spec.labels, which takes precedence when it sets the same key.
+	LabelKey string `json:"labelKey,omitempty"`
 }
 
 // NamespacelabelStatus defines the observed state of Namespacelabel
@@ -33,13 +231,84 @@ type NamespacelabelStatus struct {
 	// This map includes key-value pairs of all successfully applied labels.
 	AppliedLabels map[string]string `json:"appliedLabels,omitempty"`
 
+	// AppliedAt records, for each key in AppliedLabels, when it was first applied. The time is kept across reconciles
+	// while the key stays applied, and starts over when the key is applied again after being dropped.
+	AppliedAt map[string]metav1.Time `json:"appliedAt,omitempty"`
+
 	// Conditions is a list of conditions that provide additional insight into the status of the Namespacelabel.
 	// Conditions can include statuses like LabelsApplied, LabelsSkipped, and others.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// ActiveConditions lists the types of the conditions that are currently True, sorted,
+	// so clients can check for a condition without walking Conditions.
+	ActiveConditions []string `json:"activeConditions,omitempty"`
+
 	// SkippedLabels represents the labels that could not be applied due to conflicts or other restrictions.
 	// This map includes key-value pairs of all labels that were skipped.
 	SkippedLabels map[string]string `json:"skippedLabels,omitempty"`
+
+	// SkipReasons maps each key in SkippedLabels to the reason it was skipped, such as Protected or NamespaceExcluded.
+	SkipReasons map[string]SkipReason `json:"skipReasons,omitempty"`
+
+	// AppliedAnnotations represents the annotations from spec.annotations that were successfully applied to the namespace.
+	AppliedAnnotations map[string]string `json:"appliedAnnotations,omitempty"`
+
+	// SkippedAnnotations represents the annotations from spec.annotations that were not applied because they are protected.
+	SkippedAnnotations map[string]string `json:"skippedAnnotations,omitempty"`
+
+	// NamespaceUID is the UID of the namespace the labels were last applied to.
+	// A different UID means the namespace was deleted and recreated, so previously applied labels are not carried over.
+	NamespaceUID types.UID `json:"namespaceUID,omitempty"`
+
+	// TargetNamespace is the name of the namespace the labels were last applied to, and the one cleaned up on deletion.
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// AppliedChecksum is a SHA-256 checksum of AppliedLabels. Each reconcile compares it against the namespace's
+	// current values of those labels to cheaply detect tampering.
+	AppliedChecksum string `json:"appliedChecksum,omitempty"`
+
+	// AppliedTimestamp is the labels.dana.io/last-applied value this Namespacelabel last wrote. The annotation is
+	// shared by every Namespacelabel targeting the namespace, so it is only removed while it still holds this value.
+	AppliedTimestamp string `json:"appliedTimestamp,omitempty"`
+
+	// History lists the most recent distinct sets of applied labels, oldest first, so recent changes can be seen
+	// without external audit logs. Its length is bounded by the operator's STATUS_HISTORY_LIMIT.
+	History []HistoryEntry `json:"history,omitempty"`
+
+	// ObservedLabels mirrors the target namespace's current labels when spec.observeOnly is set.
+	ObservedLabels map[string]string `json:"observedLabels,omitempty"`
+
+	// Phase is Failed once reconciles of the current generation failed more times in a row than the operator's
+	// MAX_RECONCILE_FAILURES allows. The Namespacelabel is then no longer retried until its spec changes, and the
+	// ReconcileError condition holds the last error. It is empty otherwise.
+	Phase NamespacelabelPhase `json:"phase,omitempty"`
+
+	// ConsecutiveFailures counts the reconciles of FailedGeneration that failed in a row.
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// FailedGeneration is the generation ConsecutiveFailures was counted for. A new generation restarts the count.
+	FailedGeneration int64 `json:"failedGeneration,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the labels were last applied from. Status reflects the
+	// latest spec once it equals metadata.generation.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ForceReconcileNonce is the last value of the labels.dana.io/force-reconcile annotation the controller handled.
+	ForceReconcileNonce string `json:"forceReconcileNonce,omitempty"`
+}
+
+// NamespacelabelPhase is the phase reported in NamespacelabelStatus.Phase.
+type NamespacelabelPhase string
+
+// PhaseFailed means the Namespacelabel gave up retrying its current generation.
+const PhaseFailed NamespacelabelPhase = "Failed"
+
+// HistoryEntry records a set of applied labels and when it was first applied.
+type HistoryEntry struct {
+	// Time is when the set of labels was applied.
+	Time metav1.Time `json:"time"`
+	// Labels is the set of applied labels.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // +kubebuilder:object:root=true
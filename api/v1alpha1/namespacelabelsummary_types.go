@@ -0,0 +1,71 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SummaryName is the name of the NamespacelabelSummary singleton maintained by the operator.
+const SummaryName = "cluster"
+
+// NamespacelabelSummaryStatus defines the observed state of NamespacelabelSummary
+type NamespacelabelSummaryStatus struct {
+	// Namespaces lists every namespace that carries labels applied by a Namespacelabel, sorted by name.
+	Namespaces []ManagedNamespace `json:"namespaces,omitempty"`
+
+	// TotalAppliedLabels is the number of labels applied across all namespaces.
+	TotalAppliedLabels int32 `json:"totalAppliedLabels,omitempty"`
+}
+
+// ManagedNamespace summarises the labels applied to one namespace.
+type ManagedNamespace struct {
+	// Name is the name of the namespace.
+	Name string `json:"name"`
+
+	// AppliedLabels is the number of labels applied to the namespace.
+	AppliedLabels int32 `json:"appliedLabels"`
+
+	// Namespacelabels is the number of Namespacelabels that applied labels to the namespace.
+	Namespacelabels int32 `json:"namespacelabels"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+
+// NamespacelabelSummary is a cluster-wide view of the namespaces managed by Namespacelabels.
+// The operator maintains a single instance named SummaryName and updates it on every reconcile.
+type NamespacelabelSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status NamespacelabelSummaryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespacelabelSummaryList contains a list of NamespacelabelSummary objects.
+type NamespacelabelSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespacelabelSummary `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NamespacelabelSummary{}, &NamespacelabelSummaryList{})
+}
@@ -25,6 +25,79 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HistoryEntry) DeepCopyInto(out *HistoryEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HistoryEntry.
+func (in *HistoryEntry) DeepCopy() *HistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(HistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedNamespace) DeepCopyInto(out *ManagedNamespace) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedNamespace.
+func (in *ManagedNamespace) DeepCopy() *ManagedNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Namespacelabel) DeepCopyInto(out *Namespacelabel) {
 	*out = *in
@@ -94,6 +167,102 @@ func (in *NamespacelabelSpec) DeepCopyInto(out *NamespacelabelSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PropagateTo != nil {
+		in, out := &in.PropagateTo, &out.PropagateTo
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelOrder != nil {
+		in, out := &in.LabelOrder, &out.LabelOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TeamRef != nil {
+		in, out := &in.TeamRef, &out.TeamRef
+		*out = new(TeamReference)
+		**out = **in
+	}
+	if in.ApplyAfter != nil {
+		in, out := &in.ApplyAfter, &out.ApplyAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.NamespaceLabelSelector != nil {
+		in, out := &in.NamespaceLabelSelector, &out.NamespaceLabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImmutableKeys != nil {
+		in, out := &in.ImmutableKeys, &out.ImmutableKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelsFrom != nil {
+		in, out := &in.LabelsFrom, &out.LabelsFrom
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Transforms != nil {
+		in, out := &in.Transforms, &out.Transforms
+		*out = make(map[string]LabelTransform, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ValuePatterns != nil {
+		in, out := &in.ValuePatterns, &out.ValuePatterns
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ValueEnums != nil {
+		in, out := &in.ValueEnums, &out.ValueEnums
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal, outVal := &val, &outVal
+				*outVal = make([]string, len(*inVal))
+				copy(*outVal, *inVal)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Requires != nil {
+		in, out := &in.Requires, &out.Requires
+		*out = new(ResourceReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacelabelSpec.
@@ -116,6 +285,13 @@ func (in *NamespacelabelStatus) DeepCopyInto(out *NamespacelabelStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.AppliedAt != nil {
+		in, out := &in.AppliedAt, &out.AppliedAt
+		*out = make(map[string]v1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -123,6 +299,11 @@ func (in *NamespacelabelStatus) DeepCopyInto(out *NamespacelabelStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ActiveConditions != nil {
+		in, out := &in.ActiveConditions, &out.ActiveConditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SkippedLabels != nil {
 		in, out := &in.SkippedLabels, &out.SkippedLabels
 		*out = make(map[string]string, len(*in))
@@ -130,6 +311,41 @@ func (in *NamespacelabelStatus) DeepCopyInto(out *NamespacelabelStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.SkipReasons != nil {
+		in, out := &in.SkipReasons, &out.SkipReasons
+		*out = make(map[string]SkipReason, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AppliedAnnotations != nil {
+		in, out := &in.AppliedAnnotations, &out.AppliedAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SkippedAnnotations != nil {
+		in, out := &in.SkippedAnnotations, &out.SkippedAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]HistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedLabels != nil {
+		in, out := &in.ObservedLabels, &out.ObservedLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacelabelStatus.
@@ -141,3 +357,111 @@ func (in *NamespacelabelStatus) DeepCopy() *NamespacelabelStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacelabelSummary) DeepCopyInto(out *NamespacelabelSummary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacelabelSummary.
+func (in *NamespacelabelSummary) DeepCopy() *NamespacelabelSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacelabelSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacelabelSummary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacelabelSummaryList) DeepCopyInto(out *NamespacelabelSummaryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespacelabelSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacelabelSummaryList.
+func (in *NamespacelabelSummaryList) DeepCopy() *NamespacelabelSummaryList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacelabelSummaryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacelabelSummaryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacelabelSummaryStatus) DeepCopyInto(out *NamespacelabelSummaryStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]ManagedNamespace, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacelabelSummaryStatus.
+func (in *NamespacelabelSummaryStatus) DeepCopy() *NamespacelabelSummaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacelabelSummaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceReference.
+func (in *ResourceReference) DeepCopy() *ResourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamReference) DeepCopyInto(out *TeamReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamReference.
+func (in *TeamReference) DeepCopy() *TeamReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamReference)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -4,21 +4,29 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"strconv"
+	"time"
 
 	labelsv1alpha1 "github.com/matanamar10/namespacelabel-operator/api/v1alpha1"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	"github.com/matanamar10/namespacelabel-operator/internal/controller"
+	"github.com/matanamar10/namespacelabel-operator/internal/events"
+	"github.com/matanamar10/namespacelabel-operator/internal/notify"
+	"github.com/matanamar10/namespacelabel-operator/internal/throttle"
 	webhooklabelsv1alpha1 "github.com/matanamar10/namespacelabel-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
@@ -40,6 +48,13 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var webhookRequeueAfter time.Duration
+	var maxConcurrentReconciles int
+	var cleanupWorkers int
+	var cleanupBackoff time.Duration
+	var statusBatchWindow time.Duration
+	var protectedConfigMapName string
+	var protectedConfigMapNamespace string
 	var tlsOpts []func(*tls.Config)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
@@ -52,6 +67,20 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.DurationVar(&webhookRequeueAfter, "webhook-requeue-after", 5*time.Second,
+		"How long a reconcile waits before retrying while the webhook server is not ready yet.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"How many Namespacelabels are reconciled in parallel.")
+	flag.IntVar(&cleanupWorkers, "cleanup-workers", 2,
+		"How many deleted Namespacelabels are cleaned up at once. Use 0 for no bound.")
+	flag.DurationVar(&cleanupBackoff, "cleanup-backoff", 2*time.Second,
+		"How long a deleted Namespacelabel waits before retrying while every cleanup worker is busy.")
+	flag.DurationVar(&statusBatchWindow, "status-batch-window", 0,
+		"How long status writes of Namespacelabels in the same namespace are coalesced. Use 0 to write status immediately.")
+	flag.StringVar(&protectedConfigMapName, "protected-labels-configmap-name", "",
+		"The ConfigMap whose protected-labels.json key holds the protected labels. Leave empty to read PROTECTED_LABELS instead.")
+	flag.StringVar(&protectedConfigMapNamespace, "protected-labels-configmap-namespace", "",
+		"The namespace of the protected labels ConfigMap.")
 
 	opts := zap.Options{
 		Development: true,
@@ -63,6 +92,11 @@ func main() {
 	logger := zap.New(zap.UseFlagOptions(&opts))
 	ctrl.SetLogger(logger)
 
+	if protectedConfigMapName != "" && protectedConfigMapNamespace == "" {
+		setupLog.Error(nil, "--protected-labels-configmap-namespace is required with --protected-labels-configmap-name")
+		os.Exit(1)
+	}
+
 	disableHTTP2 := func(c *tls.Config) {
 		setupLog.Info("disabling http/2")
 		c.NextProtos = []string{"http/1.1"}
@@ -88,23 +122,77 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "09f6f97c.dana.io",
+		// ConfigMaps are read by name from the API server rather than cached: a cached read would start an
+		// informer holding every ConfigMap in the cluster. The controller watches their metadata only.
+		Client: client.Options{Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.ConfigMap{}}}},
 	})
 	if err != nil {
 		logger.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	if err = (&controller.NamespacelabelReconciler{
-		Client:   mgr.GetClient(),
-		Log:      logger,
-		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("NamespacelabelController"),
-	}).SetupWithManager(mgr); err != nil {
+	// nolint:goconst
+	enableWebhooks := os.Getenv("ENABLE_WEBHOOKS") != "false"
+
+	recorder := mgr.GetEventRecorderFor("NamespacelabelController")
+	if centralNamespace := os.Getenv(events.CentralNamespaceEnv); centralNamespace != "" {
+		recorder = events.NewMirroringRecorder(recorder, mgr.GetScheme(), centralNamespace, logger)
+	}
+	if maxEvents := os.Getenv(events.MaxEventsPerMinuteEnv); maxEvents != "" {
+		perMinute, err := strconv.Atoi(maxEvents)
+		if err != nil || perMinute <= 0 {
+			setupLog.Error(err, "invalid event rate limit, expected a positive integer", "env", events.MaxEventsPerMinuteEnv, "value", maxEvents)
+			os.Exit(1)
+		}
+		recorder = events.NewRateLimitedRecorder(recorder, perMinute, logger)
+	}
+
+	k8sClient := mgr.GetClient()
+	if writeQPS := os.Getenv(throttle.NamespaceWriteQPSEnv); writeQPS != "" {
+		qps, err := strconv.ParseFloat(writeQPS, 64)
+		if err != nil || qps <= 0 {
+			setupLog.Error(err, "invalid namespace write rate limit, expected a positive number", "env", throttle.NamespaceWriteQPSEnv, "value", writeQPS)
+			os.Exit(1)
+		}
+		burst := 1
+		if writeBurst := os.Getenv(throttle.NamespaceWriteBurstEnv); writeBurst != "" {
+			if burst, err = strconv.Atoi(writeBurst); err != nil || burst <= 0 {
+				setupLog.Error(err, "invalid namespace write burst, expected a positive integer", "env", throttle.NamespaceWriteBurstEnv, "value", writeBurst)
+				os.Exit(1)
+			}
+		}
+		k8sClient = throttle.NewNamespaceWriteLimitedClient(k8sClient, qps, burst)
+	}
+
+	reconciler := &controller.NamespacelabelReconciler{
+		Client:                  k8sClient,
+		APIReader:               mgr.GetAPIReader(),
+		Log:                     logger,
+		Scheme:                  mgr.GetScheme(),
+		Recorder:                recorder,
+		WebhookRequeueAfter:     webhookRequeueAfter,
+		FieldManager:            os.Getenv(controller.FieldManagerEnv),
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		CleanupWorkers:          cleanupWorkers,
+		CleanupBackoff:          cleanupBackoff,
+		StatusBatchWindow:       statusBatchWindow,
+		ProtectedConfigMap:      types.NamespacedName{Namespace: protectedConfigMapNamespace, Name: protectedConfigMapName},
+	}
+	if notifyURL := os.Getenv(notify.WebhookURLEnv); notifyURL != "" {
+		reconciler.Notifier = notify.NewNotifier(notifyURL, logger)
+	}
+	if enableWebhooks {
+		reconciler.WebhookReady = mgr.GetWebhookServer().StartedChecker()
+	}
+	if err = reconciler.SetupWithManager(mgr); err != nil {
 		logger.Error(err, "unable to create controller", "controller", "Namespacelabel")
 		os.Exit(1)
 	}
-	// nolint:goconst
-	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+	if enableWebhooks {
+		if err = webhooklabelsv1alpha1.ValidateConfig(); err != nil {
+			setupLog.Error(err, "invalid webhook configuration")
+			os.Exit(1)
+		}
 		if err = webhooklabelsv1alpha1.SetupNamespacelabelWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Namespacelabel")
 			os.Exit(1)
@@ -120,6 +208,12 @@ func main() {
 		logger.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if enableWebhooks {
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			logger.Error(err, "unable to set up webhook ready check")
+			os.Exit(1)
+		}
+	}
 
 	logger.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -0,0 +1,69 @@
+package conditions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// MessageTemplatesEnv names the environment variable holding custom condition messages, as a JSON object mapping a
+// condition type, or a "<type>/<reason>" pair, to a text/template. The more specific "<type>/<reason>" entry wins.
+// Conditions without an entry keep the operator's own message, which templates can include as {{ .Message }}.
+const MessageTemplatesEnv = "CONDITION_MESSAGE_TEMPLATES"
+
+// Data is what a condition message template is executed against.
+type Data struct {
+	// Type, Status and Reason are those of the condition being set.
+	Type   string
+	Status string
+	Reason string
+	// Message is the operator's own message for the condition.
+	Message string
+	// Namespace and Name identify the Namespacelabel the condition is set on.
+	Namespace string
+	Name      string
+}
+
+// Templates holds the parsed condition message templates, keyed like MessageTemplatesEnv.
+type Templates map[string]*template.Template
+
+// LoadTemplates parses the templates configured in MessageTemplatesEnv. It returns no templates when the variable
+// is unset.
+func LoadTemplates() (Templates, error) {
+	templatesJSON := os.Getenv(MessageTemplatesEnv)
+	if templatesJSON == "" {
+		return nil, nil
+	}
+	var sources map[string]string
+	if err := json.Unmarshal([]byte(templatesJSON), &sources); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", MessageTemplatesEnv, err)
+	}
+	templates := make(Templates, len(sources))
+	for key, source := range sources {
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s template for %s: %w", MessageTemplatesEnv, key, err)
+		}
+		templates[key] = tmpl
+	}
+	return templates, nil
+}
+
+// Render returns the message for the condition described by data, from its most specific template. Without a
+// matching template the operator's own message is returned unchanged.
+func (t Templates) Render(data Data) (string, error) {
+	tmpl, ok := t[data.Type+"/"+data.Reason]
+	if !ok {
+		tmpl, ok = t[data.Type]
+	}
+	if !ok {
+		return data.Message, nil
+	}
+	var message strings.Builder
+	if err := tmpl.Execute(&message, data); err != nil {
+		return "", fmt.Errorf("failed to render %s message template: %w", data.Type, err)
+	}
+	return message.String(), nil
+}
@@ -17,95 +17,764 @@ limitations under the License.
 package controller
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"context"
 
 	"github.com/go-logr/logr"
 	labelsv1alpha1 "github.com/matanamar10/namespacelabel-operator/api/v1alpha1"
+	"github.com/matanamar10/namespacelabel-operator/internal/conditions"
+	"github.com/matanamar10/namespacelabel-operator/internal/denylist"
 	"github.com/matanamar10/namespacelabel-operator/internal/finalizer"
 	"github.com/matanamar10/namespacelabel-operator/internal/labels"
+	"github.com/matanamar10/namespacelabel-operator/internal/metrics"
+	"github.com/matanamar10/namespacelabel-operator/internal/notify"
+	"github.com/matanamar10/namespacelabel-operator/internal/propagation"
+	"github.com/matanamar10/namespacelabel-operator/internal/vars"
+	"github.com/matanamar10/namespacelabel-operator/internal/window"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// FieldManagerEnv names the environment variable that overrides the field manager used for namespace writes.
+const FieldManagerEnv = "FIELD_MANAGER"
+
+// DefaultFieldManager is the field manager used for namespace writes when none is configured.
+const DefaultFieldManager = "namespacelabel-operator"
+
+// GuardEmptySpecEnv names the environment variable that, when "true", holds back reconciles of a Namespacelabel
+// whose spec.labels became empty until the change is confirmed with labels.ConfirmEmptySpecAnnotation.
+const GuardEmptySpecEnv = "GUARD_EMPTY_SPEC"
+
+// MaxTotalLabelBytesEnv names the environment variable capping the combined bytes of the keys and values the
+// operator applies to a namespace. Labels beyond the budget, in application order, are skipped.
+const MaxTotalLabelBytesEnv = "MAX_TOTAL_LABEL_BYTES"
+
+// EventsOnNamespaceEnv names the environment variable that, when "true", makes every reconcile that writes status
+// also record a summary event on the target Namespace, so that describing the namespace shows operator activity.
+const EventsOnNamespaceEnv = "EVENTS_ON_NAMESPACE"
+
+// StatusHistoryLimitEnv names the environment variable bounding how many sets of applied labels are kept in
+// status.history. Defaults to defaultStatusHistoryLimit; 0 disables the history.
+const StatusHistoryLimitEnv = "STATUS_HISTORY_LIMIT"
+
+// defaultStatusHistoryLimit is how many history entries are kept when no limit is configured.
+const defaultStatusHistoryLimit = 10
+
+// AggregateManagedLabelsEnv names the environment variable that, when "true", keeps labels.ManagedLabelsAnnotation on
+// every target namespace up to date with the labels all Namespacelabels targeting it applied, and their owners.
+const AggregateManagedLabelsEnv = "AGGREGATE_MANAGED_LABELS"
+
+// PrintPlansEnv names the environment variable that, when "true", prints every computed plan to stdout as a single
+// JSON line, apart from the logger and its formatting, so that log shippers can parse it reliably.
+const PrintPlansEnv = "PRINT_PLANS"
+
+// ShadowModeEnv names the environment variable that, when "true", runs the whole operator as if every Namespacelabel
+// set spec.dryRun: plans are computed and recorded, but no namespace is ever written, including on deletion.
+// It lets the operator be validated against a live cluster before writes are enabled.
+const ShadowModeEnv = "SHADOW_MODE"
+
+// CheckNamespaceAccessEnv names the environment variable that, when "true", makes every apply first ask the API server
+// with a SelfSubjectAccessReview whether the operator may update the target namespace. A namespace it may not update is
+// skipped with the NoPermission condition and retried after noPermissionRequeueAfter, instead of failing the reconcile.
+const CheckNamespaceAccessEnv = "CHECK_NAMESPACE_ACCESS"
+
+// MaxReconcileFailuresEnv names the environment variable bounding how many reconciles of the same generation may fail
+// in a row. Once the bound is reached the Namespacelabel enters the Failed phase and is not retried until its spec
+// changes. Unset or 0 retries forever.
+const MaxReconcileFailuresEnv = "MAX_RECONCILE_FAILURES"
+
+// StickyGracePeriodEnv names the environment variable enabling sticky mode, as a duration such as "10m". A deleted
+// Namespacelabel then leaves its labels on the namespace for that long, recorded in labels.StickyAnnotation, and one
+// recreated with the same namespace and name in the meantime adopts them instead of the labels being removed and
+// added again. This smooths GitOps tools that delete and recreate objects. Labels not adopted are removed once the
+// period ends, by a reconcile of the namespace's sticky records; see reconcileSticky. Unset or 0 removes the labels on
+// deletion.
+const StickyGracePeriodEnv = "STICKY_GRACE_PERIOD"
+
+// noPermissionRequeueAfter is how long to wait before checking a forbidden namespace again. RBAC changes do not
+// trigger reconciles, so the check is repeated on a timer.
+const noPermissionRequeueAfter = 5 * time.Minute
+
+// dependencyRequeueAfter is how long to wait before checking again for a missing spec.requires resource.
+const dependencyRequeueAfter = 30 * time.Second
+
+// defaultCleanupBackoff is how long a deleted Namespacelabel waits for a free cleanup worker when no backoff is configured.
+const defaultCleanupBackoff = 2 * time.Second
+
+// defaultWebhookRequeueAfter is how long a reconcile waits for the webhook server when no interval is configured.
+const defaultWebhookRequeueAfter = 5 * time.Second
+
 // NamespacelabelReconciler reconciles a Namespacelabel object
 type NamespacelabelReconciler struct {
 	client.Client
 	Log      logr.Logger
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// WebhookReady reports whether the admission webhook guarding Namespacelabel writes is serving.
+	// Every write the controller makes to a Namespacelabel passes through it, so reconciles are requeued
+	// until it succeeds. Leave it nil when webhooks are disabled.
+	WebhookReady healthz.Checker
+	// WebhookRequeueAfter is how long to wait before retrying while the webhook is not ready.
+	WebhookRequeueAfter time.Duration
+	// FieldManager attributes the operator's namespace writes in managed fields and audit logs.
+	// Defaults to DefaultFieldManager.
+	FieldManager string
+	// APIReader reads straight from the API server, bypassing the cache. It is used where a fresh read matters,
+	// such as verifying label cleanup. Defaults to the Client.
+	APIReader client.Reader
+	// Clock provides the current time to everything time-dependent: condition transition times, the apply
+	// timestamp, spec.applyAfter and spec.maintenanceWindow. Defaults to the real clock.
+	Clock clock.PassiveClock
+	// Notifier posts a notification after each apply that wrote the namespace and after each cleanup, or only after
+	// effective changes for Namespacelabels setting spec.notifyOnChangeOnly. Leave it nil to send no notifications.
+	Notifier *notify.Notifier
+	// MaxConcurrentReconciles is how many Namespacelabels are reconciled in parallel. Defaults to 1.
+	MaxConcurrentReconciles int
+	// CleanupWorkers bounds how many deleted Namespacelabels are cleaned up at once, so that deleting many of them
+	// together does not flood the API server with namespace writes. Zero or less means no bound.
+	CleanupWorkers int
+	// CleanupBackoff is how long a deleted Namespacelabel waits before retrying when every cleanup worker is busy.
+	// Defaults to defaultCleanupBackoff.
+	CleanupBackoff time.Duration
+	// StatusBatchWindow, when positive, coalesces status writes: queued statuses are flushed once per window by a
+	// runnable of the manager, and only the latest status of each Namespacelabel is written. Bursts, such as the
+	// namespace watch enqueueing many Namespacelabels at once, then cost one status write per Namespacelabel. A
+	// reconcile whose status is still queued requeues after the window, so a write lost to a failure or a restart is
+	// made again. Zero writes status immediately.
+	StatusBatchWindow time.Duration
+	// ProtectedConfigMap, when its name is set, is the ConfigMap the protected label rules are read from, in place of
+	// the PROTECTED_LABELS environment variable. It is read on every reconcile, so the rules can change without a
+	// restart. While it is missing or malformed, reconciles fail without applying or removing anything.
+	ProtectedConfigMap types.NamespacedName
+
+	webhookServing  atomic.Bool
+	cleanupSlots    chan struct{}
+	cleanupSlotsSet sync.Once
+	// namespaceLocks holds a *sync.Mutex per target namespace name, see lockNamespace.
+	namespaceLocks sync.Map
+	// statusBatches holds the status writes queued per Namespacelabel namespace, see queueStatus.
+	statusBatches   map[string]map[types.NamespacedName]*labelsv1alpha1.NamespacelabelStatus
+	statusBatchesMu sync.Mutex
+	// protectedSnapshot holds the rules last loaded from the ProtectedConfigMap, see loadProtected.
+	protectedSnapshot atomic.Pointer[protectedSnapshot]
+}
+
+// protectedSnapshot is one load of the ProtectedConfigMap: its rules, or the error loading them. Snapshots are never
+// modified once stored, so reconciles holding one are unaffected by a concurrent reload.
+type protectedSnapshot struct {
+	rules []labels.ProtectedRule
+	err   error
 }
 
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=services;serviceaccounts,verbs=get;list;patch
+// +kubebuilder:rbac:groups=apps,resources=daemonsets;deployments;statefulsets,verbs=get;list;patch
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=selfsubjectaccessreviews;subjectaccessreviews,verbs=create
+// +kubebuilder:rbac:groups=labels.dana.io,resources=namespacelabelsummaries;namespacelabelsummaries/status,verbs=get;list;watch;create;update
+
 func (r *NamespacelabelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.Log.Info("Starting reconciliation", "NamespacedName", req.NamespacedName)
+	if req.Namespace == "" {
+		return r.reconcileSticky(ctx, req.Name)
+	}
+	if !r.isWebhookServing() {
+		requeueAfter := r.WebhookRequeueAfter
+		if requeueAfter <= 0 {
+			requeueAfter = defaultWebhookRequeueAfter
+		}
+		r.Log.Info("Webhook server is not ready yet, requeueing", "NamespacedName", req.NamespacedName, "requeueAfter", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	var namespaceLabel labelsv1alpha1.Namespacelabel
 	if err := r.Get(ctx, req.NamespacedName, &namespaceLabel); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(fmt.Errorf("failed to get namespace label: %w", err))
 	}
 
+	if namespaceLabel.Status.Phase == labelsv1alpha1.PhaseFailed && namespaceLabel.Status.FailedGeneration == namespaceLabel.Generation &&
+		namespaceLabel.DeletionTimestamp.IsZero() {
+		r.Log.Info("Namespacelabel failed permanently, waiting for a spec change", "NamespacedName", req.NamespacedName, "generation", namespaceLabel.Generation)
+		return ctrl.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, req, &namespaceLabel)
+	if r.statusQueued(req.NamespacedName) && (result.RequeueAfter == 0 || result.RequeueAfter > r.StatusBatchWindow) {
+		// The reconcile is not done until its status is written; the requeue finds it written or writes it again.
+		result.RequeueAfter = r.StatusBatchWindow
+	}
+	return result, r.reportReconcileError(ctx, &namespaceLabel, err)
+}
+
+// reconcile brings the namespace targeted by the Namespacelabel in line with its spec, or cleans it up once the
+// Namespacelabel is being deleted.
+func (r *NamespacelabelReconciler) reconcile(ctx context.Context, req ctrl.Request, namespaceLabel *labelsv1alpha1.Namespacelabel) (ctrl.Result, error) {
 	r.Log.Info("Handling deletion for Namespacelabel", "namespace", namespaceLabel.Namespace)
 	if !namespaceLabel.ObjectMeta.DeletionTimestamp.IsZero() {
-		if err := finalizer.Cleanup(ctx, r.Client, &namespaceLabel, r.Log); err != nil {
+		if shadowMode() {
+			r.Log.Info("Shadow mode, leaving the namespace untouched on deletion", "NamespacedName", req.NamespacedName)
+			return ctrl.Result{}, finalizer.Remove(ctx, r.Client, namespaceLabel, r.Log)
+		}
+		if namespaceLabel.Spec.ObserveOnly {
+			r.Log.Info("Observe-only Namespacelabel, leaving the namespace untouched on deletion", "NamespacedName", req.NamespacedName)
+			return ctrl.Result{}, finalizer.Remove(ctx, r.Client, namespaceLabel, r.Log)
+		}
+		release, ok := r.acquireCleanupWorker()
+		if !ok {
+			backoff := r.cleanupBackoff()
+			r.Log.Info("All cleanup workers are busy, requeueing", "NamespacedName", req.NamespacedName, "requeueAfter", backoff)
+			return ctrl.Result{RequeueAfter: backoff}, nil
+		}
+		defer release()
+		defer r.lockNamespace(finalizer.TargetNamespace(namespaceLabel))()
+		kept, err := r.keepSticky(ctx, namespaceLabel)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to handle deletion: %w", err)
+		}
+		if kept {
+			if err := finalizer.Remove(ctx, r.Client, namespaceLabel, r.Log); err != nil {
+				return ctrl.Result{}, err
+			}
+		} else if err := finalizer.Cleanup(ctx, r.Client, r.apiReader(), namespaceLabel, r.fieldManager(), r.Log); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to handle deletion: %w", err)
 		}
+		metrics.ResetManagedLabels(finalizer.TargetNamespace(namespaceLabel), client.ObjectKeyFromObject(namespaceLabel).String())
+		if err := r.aggregateManagedLabels(ctx, namespaceLabel, finalizer.TargetNamespace(namespaceLabel)); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.updateSummary(ctx, namespaceLabel)
+		if kept || (namespaceLabel.Spec.NotifyOnChangeOnly && len(namespaceLabel.Status.AppliedLabels) == 0) {
+			return ctrl.Result{}, nil
+		}
+		r.Notifier.Notify(notify.Notification{
+			Event:          notify.EventCleanedUp,
+			Namespacelabel: req.NamespacedName.String(),
+			Namespace:      finalizer.TargetNamespace(namespaceLabel),
+			Time:           r.now(),
+		})
 		return ctrl.Result{}, nil
 	}
 
-	if err := finalizer.Ensure(ctx, r.Client, &namespaceLabel, r.Log); err != nil {
+	if namespaceLabel.Namespace == "" {
+		return ctrl.Result{}, r.markMissingNamespace(ctx, namespaceLabel)
+	}
+
+	if namespaceLabel.Spec.ObserveOnly {
+		return ctrl.Result{}, r.observe(ctx, namespaceLabel)
+	}
+
+	if err := finalizer.Ensure(ctx, r.Client, namespaceLabel, r.Log); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	protectedLabels, err := labels.LoadProtected(r.Log)
+	if err := r.clearDryRunPlan(ctx, namespaceLabel); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	team, err := r.ensureTeamOwner(ctx, namespaceLabel)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	configMapLabels, err := r.fetchLabelsFrom(ctx, namespaceLabel)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	specLabels, renderErrs, err := r.renderLabels(ctx, namespaceLabel)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to load the protected labels list: %w", err)
+		return ctrl.Result{}, err
+	}
+	desired := desiredLabels(namespaceLabel, specLabels, team, configMapLabels)
+	for key := range renderErrs {
+		delete(desired, key)
+	}
+
+	if wait := r.untilApplyAfter(namespaceLabel); wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, r.markScheduled(ctx, namespaceLabel)
 	}
 
-	namespace, err := r.fetchNamespace(ctx, namespaceLabel.Namespace)
+	opensAt, err := r.nextWindowOpen(namespaceLabel)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	if !opensAt.IsZero() {
+		return ctrl.Result{RequeueAfter: opensAt.Sub(r.now())}, r.markOutsideWindow(ctx, namespaceLabel, opensAt)
+	}
 
-	updatedLabels, skippedLabels, duplicateLabels := r.processLabels(namespace, &namespaceLabel, protectedLabels)
+	found, err := r.requiredResourceExists(ctx, namespaceLabel)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !found {
+		return ctrl.Result{RequeueAfter: dependencyRequeueAfter}, r.markDependencyMissing(ctx, namespaceLabel)
+	}
 
-	for key, value := range updatedLabels {
-		namespace.Labels[key] = value
+	protectedRules, err := r.loadProtected(ctx)
+	if err != nil {
+		return ctrl.Result{}, r.markConfigLoadFailed(ctx, namespaceLabel, err)
+	}
+	protectedAnnotationRules, err := labels.LoadProtectedAnnotations(r.Log)
+	if err != nil {
+		return ctrl.Result{}, r.markConfigLoadFailed(ctx, namespaceLabel, err)
+	}
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionConfigLoaded, metav1.ConditionTrue, labelsv1alpha1.ReasonProtectedConfigLoaded, "Protected labels configuration loaded.")
+
+	targetName, err := r.resolveTarget(ctx, namespaceLabel)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if targetName == "" {
+		return ctrl.Result{}, r.writeStatus(ctx, namespaceLabel)
+	}
+
+	defer r.lockNamespace(targetName)()
+	namespace, err := r.fetchNamespace(ctx, targetName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	denied, err := denylist.Load(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if denied[namespace.Name] {
+		return ctrl.Result{}, r.markDenied(ctx, namespaceLabel, namespace)
+	}
+	if labels.IsOptedOut(namespace) {
+		return ctrl.Result{}, r.markOptedOut(ctx, namespaceLabel, namespace)
+	}
+	allowed, err := r.canUpdateNamespace(ctx, namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !allowed {
+		return ctrl.Result{RequeueAfter: noPermissionRequeueAfter}, r.markNoPermission(ctx, namespaceLabel, namespace)
+	}
+
+	r.restorePendingStatus(namespaceLabel)
+
+	if namespaceLabel.Status.TargetNamespace != "" && namespaceLabel.Status.TargetNamespace != namespace.Name {
+		r.Log.Info("Target namespace changed, discarding previously applied labels", "previous", namespaceLabel.Status.TargetNamespace, "current", namespace.Name)
+		r.Recorder.Event(namespaceLabel, corev1.EventTypeNormal, "TargetChanged",
+			fmt.Sprintf("Target namespace changed from %s to %s; labels already applied to %s are left in place",
+				namespaceLabel.Status.TargetNamespace, namespace.Name, namespaceLabel.Status.TargetNamespace))
+		metrics.ResetManagedLabels(namespaceLabel.Status.TargetNamespace, client.ObjectKeyFromObject(namespaceLabel).String())
+		namespaceLabel.Status.AppliedLabels = nil
+		namespaceLabel.Status.AppliedAnnotations = nil
+		namespaceLabel.Status.AppliedChecksum = ""
+		namespaceLabel.Status.AppliedTimestamp = ""
+	} else if namespaceLabel.Status.NamespaceUID != "" && namespaceLabel.Status.NamespaceUID != namespace.UID {
+		r.Log.Info("Namespace was recreated, discarding previously applied labels", "namespace", namespace.Name,
+			"previousUID", namespaceLabel.Status.NamespaceUID, "currentUID", namespace.UID)
+		r.Recorder.Event(namespaceLabel, corev1.EventTypeNormal, "NamespaceRecreated",
+			fmt.Sprintf("Namespace %s was recreated; labels will be applied from scratch", namespace.Name))
+		namespaceLabel.Status.AppliedLabels = nil
+		namespaceLabel.Status.AppliedAnnotations = nil
+		namespaceLabel.Status.AppliedChecksum = ""
+		namespaceLabel.Status.AppliedTimestamp = ""
+	}
+	namespaceLabel.Status.NamespaceUID = namespace.UID
+	namespaceLabel.Status.TargetNamespace = namespace.Name
+	if err := r.adoptSticky(ctx, namespace, namespaceLabel, desired); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.verifyChecksum(namespace, namespaceLabel)
+
+	if r.isSuspiciousEmptySpec(namespaceLabel, desired) {
+		return ctrl.Result{}, r.holdEmptySpec(ctx, namespaceLabel)
+	}
+
+	plan := r.processLabels(namespace, namespaceLabel, desired, protectedRules)
+	r.skipUnrendered(namespaceLabel, plan, renderErrs)
+	plan.annotations = r.processAnnotations(namespace, namespaceLabel, protectedAnnotationRules)
+	r.enforceSizeBudget(namespaceLabel, plan, orderedKeys(desired, namespaceLabel.Spec.LabelOrder))
+	r.printPlan(namespaceLabel, namespace, plan)
+	if isDryRun(namespaceLabel) {
+		return ctrl.Result{}, r.writeDryRunPlan(ctx, namespaceLabel, namespace, plan)
+	}
+	if protectedKeys := plan.skippedFor(labelsv1alpha1.SkipReasonProtected); namespaceLabel.Spec.StrictProtected && len(protectedKeys) > 0 {
+		return ctrl.Result{}, r.rejectStrictProtected(ctx, namespaceLabel, plan, protectedKeys)
+	}
+
+	result, err := r.applyLabels(ctx, namespace, namespaceLabel, plan, orderedKeys(desired, namespaceLabel.Spec.LabelOrder))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !result.wroteNamespace {
+		r.Log.Info("Namespace already up to date, skipped write", "namespace", namespace.Name, "unchanged", result.unchanged)
 	}
+	metrics.RecordPlan(namespace.Name, len(result.changed), len(plan.skipped), len(plan.duplicates))
 
-	if err := r.Update(ctx, namespace); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to update namespace: %w", err)
+	appliedLabels := readBackApplied(namespace, plan.updated)
+	recordAnnotationStatus(namespaceLabel, plan.annotations, readBackAppliedAnnotations(namespace, plan.annotations))
+
+	var applyErr error
+	if len(namespaceLabel.Spec.PropagateTo) > 0 {
+		if err := propagation.Apply(ctx, r.Client, namespace.Name, namespaceLabel.Spec.PropagateTo, appliedLabels, r.fieldManager(), r.Log); err != nil {
+			applyErr = fmt.Errorf("failed to propagate labels: %w", err)
+		}
 	}
 
-	if err := r.updateStatus(ctx, &namespaceLabel, updatedLabels, skippedLabels, duplicateLabels); err != nil {
+	forceNonce := takeForceReconcile(namespaceLabel)
+	if err := r.updateStatus(ctx, namespaceLabel, plan, appliedLabels, applyErr); err != nil {
+		r.recordPendingStatus(ctx, namespaceLabel, appliedLabels)
 		return ctrl.Result{}, fmt.Errorf("failed to update Namespacelabel status: %w", err)
 	}
+	r.clearPendingStatus(ctx, namespaceLabel)
+	if len(result.changed) > 0 && isCleanApply(plan, appliedLabels, applyErr) {
+		r.Recorder.Event(namespaceLabel, corev1.EventTypeNormal, "CleanApply",
+			fmt.Sprintf("All %d labels were applied to namespace %s with no skips or duplicates", len(appliedLabels), namespace.Name))
+	}
+	if forceNonce != "" {
+		r.reemitState(namespaceLabel, namespace, plan, appliedLabels, forceNonce)
+	}
+	if err := r.aggregateManagedLabels(ctx, namespaceLabel, namespace.Name); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.updateSummary(ctx, namespaceLabel)
+	r.reportOnNamespace(namespace, namespaceLabel, plan, appliedLabels)
+	if notifyApply(namespaceLabel, result) {
+		r.Notifier.Notify(notify.Notification{
+			Event:          notify.EventApplied,
+			Namespacelabel: req.NamespacedName.String(),
+			Namespace:      namespace.Name,
+			Labels:         statusValues(namespaceLabel, appliedLabels),
+			Time:           r.now(),
+		})
+	}
+	if applyErr != nil {
+		return ctrl.Result{}, applyErr
+	}
 
 	return ctrl.Result{}, nil
 }
 
+// notifyApply reports whether an apply is notified: after every namespace write, or with spec.notifyOnChangeOnly
+// only when a label was added or changed its value.
+func notifyApply(namespaceLabel *labelsv1alpha1.Namespacelabel, result applyResult) bool {
+	if namespaceLabel.Spec.NotifyOnChangeOnly {
+		return len(result.changed) > 0
+	}
+	return result.wroteNamespace
+}
+
+// takeForceReconcile returns the labels.dana.io/force-reconcile nonce when it has not been handled yet, recording it
+// in status so that it is handled once. It returns an empty string otherwise.
+func takeForceReconcile(namespaceLabel *labelsv1alpha1.Namespacelabel) string {
+	nonce := namespaceLabel.Annotations[labels.ForceReconcileAnnotation]
+	if nonce == "" || nonce == namespaceLabel.Status.ForceReconcileNonce {
+		return ""
+	}
+	namespaceLabel.Status.ForceReconcileNonce = nonce
+	return nonce
+}
+
+// reemitState records the current state of the Namespacelabel as events for a forced reconcile, including the parts
+// that are normally only reported when they change.
+func (r *NamespacelabelReconciler) reemitState(namespaceLabel *labelsv1alpha1.Namespacelabel, namespace *corev1.Namespace, plan *labelPlan, appliedLabels map[string]string, nonce string) {
+	r.Log.Info("Reconcile forced, re-emitting current state", "namespaceLabel", namespaceLabel.Name, "nonce", nonce)
+	r.Recorder.Event(namespaceLabel, corev1.EventTypeNormal, "ForcedReconcile",
+		fmt.Sprintf("Reconcile forced by %s=%s: namespace %s has %d applied, %d skipped, %d duplicates",
+			labels.ForceReconcileAnnotation, nonce, namespace.Name, len(appliedLabels), len(plan.skipped), len(plan.duplicates)))
+	if len(plan.skipped) > 0 {
+		r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "LabelsSkipped", plan.skippedMessage())
+	}
+}
+
+// reportOnNamespace records a summary of the reconcile as an event on the target namespace when
+// EVENTS_ON_NAMESPACE is enabled.
+func (r *NamespacelabelReconciler) reportOnNamespace(namespace *corev1.Namespace, namespaceLabel *labelsv1alpha1.Namespacelabel, plan *labelPlan, appliedLabels map[string]string) {
+	if os.Getenv(EventsOnNamespaceEnv) != "true" {
+		return
+	}
+	r.Recorder.Event(namespace, corev1.EventTypeNormal, "NamespacelabelReconciled",
+		fmt.Sprintf("Namespacelabel %s/%s reconciled: %d applied, %d skipped, %d duplicates", namespaceLabel.Namespace, namespaceLabel.Name, len(appliedLabels), len(plan.skipped), len(plan.duplicates)))
+}
+
+// restorePendingStatus seeds status.appliedLabels from the PendingStatusAnnotation left by a reconcile whose
+// status write failed, so the labels it applied are still recognised as owned by this CR.
+func (r *NamespacelabelReconciler) restorePendingStatus(namespaceLabel *labelsv1alpha1.Namespacelabel) {
+	pending, ok := namespaceLabel.Annotations[labels.PendingStatusAnnotation]
+	if !ok {
+		return
+	}
+	var appliedLabels map[string]string
+	if err := json.Unmarshal([]byte(pending), &appliedLabels); err != nil {
+		r.Log.Error(err, "Failed to parse pending applied labels, ignoring them", "namespace", namespaceLabel.Namespace, "name", namespaceLabel.Name)
+		return
+	}
+	r.Log.Info("Restoring applied labels from a failed status write", "namespace", namespaceLabel.Namespace, "name", namespaceLabel.Name)
+	namespaceLabel.Status.AppliedLabels = appliedLabels
+	namespaceLabel.Status.AppliedChecksum = labels.Checksum(appliedLabels)
+	if namespaceLabel.Spec.RedactStatusValues {
+		// The pending values were redacted, so they cannot be checksummed.
+		namespaceLabel.Status.AppliedChecksum = ""
+	}
+}
+
+// recordPendingStatus is a best-effort rollback for a failed status write: the namespace already carries the
+// applied labels, so they are recorded in the PendingStatusAnnotation for the next reconcile to pick up.
+func (r *NamespacelabelReconciler) recordPendingStatus(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, appliedLabels map[string]string) {
+	data, err := json.Marshal(statusValues(namespaceLabel, appliedLabels))
+	if err != nil {
+		r.Log.Error(err, "Failed to encode pending applied labels", "namespace", namespaceLabel.Namespace, "name", namespaceLabel.Name)
+		return
+	}
+	if namespaceLabel.Annotations == nil {
+		namespaceLabel.Annotations = make(map[string]string)
+	}
+	namespaceLabel.Annotations[labels.PendingStatusAnnotation] = string(data)
+	if err := r.Update(ctx, namespaceLabel); err != nil {
+		r.Log.Error(err, "Failed to record pending applied labels", "namespace", namespaceLabel.Namespace, "name", namespaceLabel.Name)
+	}
+}
+
+// clearPendingStatus drops the PendingStatusAnnotation once status has been written successfully.
+func (r *NamespacelabelReconciler) clearPendingStatus(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) {
+	if _, ok := namespaceLabel.Annotations[labels.PendingStatusAnnotation]; !ok {
+		return
+	}
+	delete(namespaceLabel.Annotations, labels.PendingStatusAnnotation)
+	if err := r.Update(ctx, namespaceLabel); err != nil {
+		r.Log.Error(err, "Failed to clear pending applied labels", "namespace", namespaceLabel.Namespace, "name", namespaceLabel.Name)
+	}
+}
+
+// applyResult describes what applyLabels did to the namespace.
+type applyResult struct {
+	// changed lists the labels whose value was added or changed, as "key=value" in application order.
+	changed []string
+	// unchanged counts the planned labels that already had the desired value.
+	unchanged int
+	// wroteNamespace reports whether the namespace was written. It is false when neither the labels, the mirror
+	// annotations, the spec hash, nor the annotations from spec.annotations changed, and no stale apply timestamp
+	// was dropped.
+	wroteNamespace bool
+}
+
+// applyLabels writes the plan's labels to the namespace in the given key order, together with its annotations, and
+// the apply timestamp and the mirror annotations when requested. The namespace is only written when something changed, with a merge patch
+// carrying just the changed keys so labels other controllers set since it was read are left alone; afterwards it
+// holds the API server's response to the write.
+func (r *NamespacelabelReconciler) applyLabels(ctx context.Context, namespace *corev1.Namespace, namespaceLabel *labelsv1alpha1.Namespacelabel, plan *labelPlan, keys []string) (applyResult, error) {
+	var result applyResult
+	original := namespace.DeepCopy()
+	for _, key := range keys {
+		value, ok := plan.updated[key]
+		if !ok {
+			continue
+		}
+		if current, exists := namespace.Labels[key]; exists && current == value {
+			result.unchanged++
+			continue
+		}
+		result.changed = append(result.changed, fmt.Sprintf("%s=%s", key, eventValue(namespaceLabel, value)))
+		namespace.Labels[key] = value
+	}
+
+	mirrorsChanged := mirrorAnnotations(namespace, namespaceLabel, plan)
+	specHashChanged := recordSpecHash(namespace, namespaceLabel, plan)
+	annotationsChanged := r.applyAnnotations(namespace, plan.annotations)
+	changed := len(result.changed) > 0 || mirrorsChanged || specHashChanged || annotationsChanged
+	timestampChanged := r.recordApplyTimestamp(namespace, namespaceLabel, changed)
+
+	if !changed && !timestampChanged {
+		return result, nil
+	}
+	if err := r.Patch(ctx, namespace, client.MergeFrom(original), client.FieldOwner(r.fieldManager())); err != nil {
+		return result, fmt.Errorf("failed to patch namespace: %w", err)
+	}
+	result.wroteNamespace = true
+
+	if len(result.changed) > 0 {
+		r.Log.Info("Applied labels", "namespace", namespace.Name, "order", result.changed)
+		if namespaceLabel.Spec.VerboseEvents {
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeNormal, "AppliedLabels", fmt.Sprintf("Applied labels in order: %s", strings.Join(result.changed, ", ")))
+		} else {
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeNormal, "AppliedLabels", fmt.Sprintf("Applied labels: %d added or changed", len(result.changed)))
+		}
+	}
+	return result, nil
+}
+
+// fieldManager returns the configured field manager, falling back to DefaultFieldManager.
+func (r *NamespacelabelReconciler) fieldManager() string {
+	if r.FieldManager == "" {
+		return DefaultFieldManager
+	}
+	return r.FieldManager
+}
+
+// acquireCleanupWorker claims one of the CleanupWorkers without waiting for it. It returns the function releasing
+// the worker, and false when every worker is busy.
+func (r *NamespacelabelReconciler) acquireCleanupWorker() (func(), bool) {
+	if r.CleanupWorkers <= 0 {
+		return func() {}, true
+	}
+	r.cleanupSlotsSet.Do(func() {
+		r.cleanupSlots = make(chan struct{}, r.CleanupWorkers)
+	})
+	select {
+	case r.cleanupSlots <- struct{}{}:
+		return func() { <-r.cleanupSlots }, true
+	default:
+		return nil, false
+	}
+}
+
+// lockNamespace serializes, within this process, the reconciles of Namespacelabels targeting the same namespace, so
+// that Namespacelabels created together do not race each other's namespace updates into conflicts. It blocks until
+// the namespace is free and returns the function releasing it. Locks are kept for the life of the process.
+func (r *NamespacelabelReconciler) lockNamespace(name string) func() {
+	value, _ := r.namespaceLocks.LoadOrStore(name, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// cleanupBackoff returns how long to wait before retrying a cleanup that found every worker busy.
+func (r *NamespacelabelReconciler) cleanupBackoff() time.Duration {
+	if r.CleanupBackoff > 0 {
+		return r.CleanupBackoff
+	}
+	return defaultCleanupBackoff
+}
+
+// apiReader returns the configured APIReader, falling back to the Client.
+func (r *NamespacelabelReconciler) apiReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
+}
+
+// isWebhookServing reports whether reconciles may proceed. Once the webhook has been seen serving,
+// the result is remembered so that later reconciles don't dial the webhook server again.
+func (r *NamespacelabelReconciler) isWebhookServing() bool {
+	if r.WebhookReady == nil || r.webhookServing.Load() {
+		return true
+	}
+	if err := r.WebhookReady(nil); err != nil {
+		r.Log.Info("Webhook readiness check failed", "reason", err.Error())
+		return false
+	}
+	r.webhookServing.Store(true)
+	return true
+}
+
 // setCondition function sets the condition for the namespacelabel object.
-func (r *NamespacelabelReconciler) setCondition(namespaceLabel *labelsv1alpha1.Namespacelabel, conditionType string, status metav1.ConditionStatus, reason, message string) {
+func (r *NamespacelabelReconciler) setCondition(namespaceLabel *labelsv1alpha1.Namespacelabel, conditionType labelsv1alpha1.ConditionType, status metav1.ConditionStatus, reason labelsv1alpha1.ConditionReason, message string) {
 	r.Log.Info("Setting condition", "type", conditionType, "status", status, "reason", reason)
 
 	condition := metav1.Condition{
-		Type:               conditionType,
+		Type:               string(conditionType),
 		Status:             status,
-		Reason:             reason,
-		Message:            message,
-		LastTransitionTime: metav1.Now(),
+		Reason:             string(reason),
+		Message:            r.conditionMessage(namespaceLabel, conditionType, status, reason, message),
+		LastTransitionTime: metav1.NewTime(r.now()),
+		ObservedGeneration: namespaceLabel.Generation,
 	}
 
 	meta.SetStatusCondition(&namespaceLabel.Status.Conditions, condition)
 }
 
+// conditionMessage returns the message for a condition, rendered from the template configured for it in
+// conditions.MessageTemplatesEnv. The given message is used as is when no template matches, and when the templates
+// cannot be loaded or rendered, so a broken configuration never leaves a condition without a message.
+func (r *NamespacelabelReconciler) conditionMessage(namespaceLabel *labelsv1alpha1.Namespacelabel, conditionType labelsv1alpha1.ConditionType, status metav1.ConditionStatus, reason labelsv1alpha1.ConditionReason, message string) string {
+	templates, err := conditions.LoadTemplates()
+	if err != nil {
+		r.Log.Error(err, "Ignoring invalid condition message templates", "env", conditions.MessageTemplatesEnv)
+		return message
+	}
+	rendered, err := templates.Render(conditions.Data{
+		Type:      string(conditionType),
+		Status:    string(status),
+		Reason:    string(reason),
+		Message:   message,
+		Namespace: namespaceLabel.Namespace,
+		Name:      namespaceLabel.Name,
+	})
+	if err != nil {
+		r.Log.Error(err, "Failed to render condition message template, using the default message", "type", conditionType)
+		return message
+	}
+	return rendered
+}
+
+// resolveTarget returns the name of the namespace to label: spec.namespaceName, the single namespace matching
+// spec.namespaceLabelSelector, or the Namespacelabel's own namespace. When the selector does not match exactly one
+// namespace, the TargetResolved condition is set False and an empty name is returned.
+func (r *NamespacelabelReconciler) resolveTarget(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) (string, error) {
+	switch {
+	case namespaceLabel.Spec.NamespaceName != "":
+		return namespaceLabel.Spec.NamespaceName, nil
+	case namespaceLabel.Spec.NamespaceLabelSelector == nil:
+		return namespaceLabel.Namespace, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(namespaceLabel.Spec.NamespaceLabelSelector)
+	if err != nil {
+		return "", fmt.Errorf("invalid spec.namespaceLabelSelector: %w", err)
+	}
+	var namespaces corev1.NamespaceList
+	if err := r.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", fmt.Errorf("failed to list namespaces matching %s: %w", selector, err)
+	}
+
+	if len(namespaces.Items) == 1 {
+		name := namespaces.Items[0].Name
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionTargetResolved, metav1.ConditionTrue, labelsv1alpha1.ReasonTargetResolved,
+			fmt.Sprintf("Selector %s matches namespace %s.", selector, name))
+		return name, nil
+	}
+
+	reason := labelsv1alpha1.ReasonNoMatchingNamespace
+	message := fmt.Sprintf("No namespace matches selector %s.", selector)
+	if len(namespaces.Items) > 1 {
+		names := make([]string, 0, len(namespaces.Items))
+		for _, namespace := range namespaces.Items {
+			names = append(names, namespace.Name)
+		}
+		sort.Strings(names)
+		reason = labelsv1alpha1.ReasonAmbiguousTarget
+		message = fmt.Sprintf("Selector %s matches %d namespaces %v; exactly one is required.", selector, len(names), names)
+	}
+	r.Log.Info("Target namespace could not be resolved, skipping apply", "namespace", namespaceLabel.Namespace, "name", namespaceLabel.Name, "reason", reason)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionTargetResolved, metav1.ConditionFalse, reason, message)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsApplied, metav1.ConditionFalse, reason, message)
+	return "", nil
+}
+
 // fetchNamespace retrieves a Namespace object by its name.
 // It fetches the Namespace resource from the Kubernetes API server using the provided client.
 func (r *NamespacelabelReconciler) fetchNamespace(ctx context.Context, namespaceName string) (*corev1.Namespace, error) {
@@ -119,94 +788,1806 @@ func (r *NamespacelabelReconciler) fetchNamespace(ctx context.Context, namespace
 	return &namespace, nil
 }
 
+// labelPlan describes how each desired label is handled by a reconcile.
+type labelPlan struct {
+	// updated holds the labels to write to the namespace.
+	updated map[string]string
+	// skipped holds the labels that are not applied, and skipReasons says why for each of them.
+	skipped     map[string]string
+	skipReasons map[string]labelsv1alpha1.SkipReason
+	// duplicates holds the labels not applied because the namespace already carries the key.
+	duplicates map[string]string
+	// overridden holds the protected keys let through because the CR is exempt from protected labels.
+	overridden map[string]bool
+	// deprecated holds the desired keys listed in DEPRECATED_LABEL_KEYS. They are applied like any other key.
+	deprecated map[string]bool
+	// annotations is the plan for spec.annotations. It is nil when the annotations were not planned.
+	annotations *annotationPlan
+}
+
+// annotationPlan describes how each annotation in spec.annotations is handled by a reconcile.
+type annotationPlan struct {
+	// updated holds the annotations to write to the namespace.
+	updated map[string]string
+	// skipped holds the annotations not applied because they are protected.
+	skipped map[string]string
+	// duplicates holds the annotations not applied because the namespace already carries the key.
+	duplicates map[string]string
+}
+
+// skip records that a label is not applied for the given reason.
+func (p *labelPlan) skip(key, value string, reason labelsv1alpha1.SkipReason) {
+	p.skipped[key] = value
+	p.skipReasons[key] = reason
+}
+
+// skippedFor returns the sorted keys skipped for the given reason.
+func (p *labelPlan) skippedFor(reason labelsv1alpha1.SkipReason) []string {
+	var keys []string
+	for key, skipReason := range p.skipReasons {
+		if skipReason == reason {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// skippedMessage describes every skipped label together with the reason it was skipped.
+func (p *labelPlan) skippedMessage() string {
+	keys := make([]string, 0, len(p.skipped))
+	for key := range p.skipped {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	details := make([]string, 0, len(keys))
+	for _, key := range keys {
+		details = append(details, fmt.Sprintf("%s (%s)", key, p.skipReasons[key]))
+	}
+	return fmt.Sprintf("Some labels were skipped: %s.", strings.Join(details, ", "))
+}
+
 // processLabels function is defining the labels for the namespacelabels object.
-func (r *NamespacelabelReconciler) processLabels(namespace *corev1.Namespace, namespaceLabel *labelsv1alpha1.Namespacelabel, protectedLabels map[string]string) (updatedLabels map[string]string, skippedLabels map[string]string, duplicateLabels map[string]string) {
+func (r *NamespacelabelReconciler) processLabels(namespace *corev1.Namespace, namespaceLabel *labelsv1alpha1.Namespacelabel, desired map[string]string, protectedRules []labels.ProtectedRule) *labelPlan {
 	r.Log.Info("Processing labels for Namespacelabel", "namespace", namespaceLabel.Namespace)
 
-	updatedLabels = make(map[string]string)
-	skippedLabels = make(map[string]string)
-	duplicateLabels = make(map[string]string)
+	plan := &labelPlan{
+		updated:     make(map[string]string),
+		skipped:     make(map[string]string),
+		skipReasons: make(map[string]labelsv1alpha1.SkipReason),
+		duplicates:  make(map[string]string),
+		overridden:  make(map[string]bool),
+		deprecated:  make(map[string]bool),
+	}
 
 	if namespace.Labels == nil {
 		namespace.Labels = make(map[string]string)
 	}
+	protected := labels.NewProtectedIndex(protectedRules)
+	excludedKeys := labels.ExcludedKeys(namespace)
+	yieldKeys := labels.YieldKeys()
+	deprecatedKeys := labels.DeprecatedKeys()
+	exemptProtected := namespaceLabel.Annotations[labels.ExemptProtectedAnnotation] == "true"
+	overwrite := namespaceLabel.Spec.OverwritePolicy == labelsv1alpha1.OverwritePolicyOverwrite
+	immutableKeys := make(map[string]bool, len(namespaceLabel.Spec.ImmutableKeys))
+	for _, key := range namespaceLabel.Spec.ImmutableKeys {
+		immutableKeys[key] = true
+	}
 
-	for key, value := range namespaceLabel.Spec.Labels {
+	for _, key := range orderedKeys(desired, namespaceLabel.Spec.LabelOrder) {
+		value := desired[key]
+		patternErr := labels.CheckValuePattern(namespaceLabel.Spec.ValuePatterns[key], value)
+		var enumErr error
+		if allowed, ok := namespaceLabel.Spec.ValueEnums[key]; ok {
+			enumErr = labels.CheckValueEnum(allowed, value)
+		}
+		isProtected := protected.IsProtected(namespace, key)
+		if exemptProtected && isProtected {
+			plan.overridden[key] = true
+		}
+		if deprecatedKeys[key] {
+			plan.deprecated[key] = true
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "DeprecatedLabel",
+				fmt.Sprintf("Label %s is deprecated and should be migrated off; it is still applied", key))
+		}
 		switch {
-		case protectedLabels[key] != "":
+		case labels.IsSystemLabel(key):
+			r.Log.Info("Skipping label that shadows a system label", "key", key, "value", value)
+			plan.skip(key, value, labelsv1alpha1.SkipReasonShadowsSystemLabel)
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "ShadowsSystemLabel", fmt.Sprintf("Label %s is maintained by Kubernetes and was not applied", key))
+
+		case labels.IsReserved(key):
+			r.Log.Info("Skipping label with the reserved prefix", "key", key, "value", value)
+			plan.skip(key, value, labelsv1alpha1.SkipReasonReservedPrefix)
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "ReservedPrefix",
+				fmt.Sprintf("Label %s uses the prefix %s reserved for the operator and was not applied", key, labels.ReservedPrefix))
+
+		case !exemptProtected && isProtected:
 			r.Log.Info("Skipping protected label", "key", key, "value", value)
-			skippedLabels[key] = value
-			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "ProtectedLabelSkipped", fmt.Sprintf("Label %s=%s is protected and was not applied", key, value))
+			plan.skip(key, value, labelsv1alpha1.SkipReasonProtected)
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "ProtectedLabelSkipped", fmt.Sprintf("Label %s=%s is protected and was not applied", key, eventValue(namespaceLabel, value)))
+
+		case excludedKeys[key]:
+			r.Log.Info("Skipping label excluded by the namespace", "key", key, "value", value)
+			plan.skip(key, value, labelsv1alpha1.SkipReasonNamespaceExcluded)
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "NamespaceExcludedLabelSkipped",
+				fmt.Sprintf("Label %s=%s is excluded by the %s annotation on namespace %s and was not applied", key, eventValue(namespaceLabel, value), labels.ExcludeAnnotation, namespace.Name))
+
+		case namespaceLabel.Spec.Transforms[key] != "" && len(value) > validation.LabelValueMaxLength:
+			r.Log.Info("Skipping label whose transformed value is too long", "key", key, "value", value)
+			plan.skip(key, value, labelsv1alpha1.SkipReasonValueTooLongAfterTransform)
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "ValueTooLongAfterTransform",
+				fmt.Sprintf("Label %s is %d characters long after the %s transform, over the limit of %d, and was not applied", key, len(value), namespaceLabel.Spec.Transforms[key], validation.LabelValueMaxLength))
+
+		case patternErr != nil:
+			r.Log.Info("Skipping label whose value does not match its pattern", "key", key, "value", value, "error", patternErr.Error())
+			plan.skip(key, value, labelsv1alpha1.SkipReasonValuePatternMismatch)
+			message := fmt.Sprintf("Label %s was not applied: %v", key, patternErr)
+			if namespaceLabel.Spec.RedactStatusValues {
+				message = fmt.Sprintf("Label %s was not applied: its value does not match pattern %q", key, namespaceLabel.Spec.ValuePatterns[key])
+			}
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "ValuePatternMismatch", message)
+
+		case enumErr != nil:
+			r.Log.Info("Skipping label whose value is not allowed", "key", key, "value", value, "error", enumErr.Error())
+			plan.skip(key, value, labelsv1alpha1.SkipReasonValueNotAllowed)
+			message := fmt.Sprintf("Label %s was not applied: %v", key, enumErr)
+			if namespaceLabel.Spec.RedactStatusValues {
+				message = fmt.Sprintf("Label %s was not applied: its value is not one of %s", key, strings.Join(namespaceLabel.Spec.ValueEnums[key], ", "))
+			}
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "ValueNotAllowed", message)
 
-		case namespace.Labels[key] != "":
+		case immutableKeys[key] && wasApplied(namespaceLabel, key):
+			if current, exists := namespace.Labels[key]; exists && current != value {
+				r.Log.Info("Reverting immutable label", "namespace", namespace.Name, "key", key, "current", current, "value", value)
+				r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "ImmutableReverted",
+					fmt.Sprintf("Immutable label %s was changed on namespace %s from %s to %s and was reverted", key, namespace.Name, eventValue(namespaceLabel, value), eventValue(namespaceLabel, current)))
+			}
+			plan.updated[key] = value
+
+		case yieldKeys[key] && wasApplied(namespaceLabel, key) && namespace.Labels[key] != "" && namespace.Labels[key] != value:
+			current := namespace.Labels[key]
+			// A redacted status cannot tell whether the change is new; ChecksumMismatch reports it instead.
+			if applied := namespaceLabel.Status.AppliedLabels[key]; applied != current && applied != labels.RedactedValue {
+				r.Log.Info("Yielding to an out-of-band label change", "namespace", namespace.Name, "key", key, "current", current, "value", value)
+				r.Recorder.Event(namespaceLabel, corev1.EventTypeNormal, "YieldedToExternalChange",
+					fmt.Sprintf("Label %s was changed on namespace %s out-of-band to %s and is kept, since the operator yields on it", key, namespace.Name, eventValue(namespaceLabel, current)))
+			}
+			plan.updated[key] = current
+
+		case wasApplied(namespaceLabel, key) && namespace.Labels[key] != "" && namespace.Labels[key] != value && labels.ValuesEqual(namespace.Labels[key], value):
+			r.Log.Info("Keeping label whose value only differs in case", "namespace", namespace.Name, "key", key, "current", namespace.Labels[key], "value", value)
+			plan.updated[key] = namespace.Labels[key]
+
+		case namespace.Labels[key] != "" && !wasApplied(namespaceLabel, key) && overwrite:
+			current := namespace.Labels[key]
+			if labels.ValuesEqual(current, value) {
+				// Only the case differs, so the existing value is kept rather than rewritten.
+				plan.updated[key] = current
+				break
+			}
+			r.Log.Info("Overwriting existing label", "namespace", namespace.Name, "key", key, "current", current, "value", value)
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeNormal, "ExistingLabelOverwritten",
+				fmt.Sprintf("Label %s on namespace %s was changed from %s to %s under the Overwrite policy", key, namespace.Name, eventValue(namespaceLabel, current), eventValue(namespaceLabel, value)))
+			plan.updated[key] = value
+
+		case namespace.Labels[key] != "" && !wasApplied(namespaceLabel, key):
 			r.Log.Info("Skipping duplicate label", "key", key, "value", value)
-			duplicateLabels[key] = value
-			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "DuplicateLabelSkipped", fmt.Sprintf("Label %s=%s already exists with value %s", key, value, namespace.Labels[key]))
+			plan.duplicates[key] = value
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "DuplicateLabelSkipped", fmt.Sprintf("Label %s=%s already exists with value %s", key, eventValue(namespaceLabel, value), eventValue(namespaceLabel, namespace.Labels[key])))
 
 		default:
+			r.detectDrift(namespace, namespaceLabel, key)
 			r.Log.Info("Adding label", "key", key, "value", value)
-			updatedLabels[key] = value
+			plan.updated[key] = value
 		}
 	}
-	return updatedLabels, skippedLabels, duplicateLabels
+	return plan
 }
 
-// The updateStatus function is updating the status to the namespacelabel reconciled object.
-func (r *NamespacelabelReconciler) updateStatus(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, updatedLabels, skippedLabels, duplicateLabels map[string]string) error {
-	namespaceLabel.Status.AppliedLabels = updatedLabels
-	namespaceLabel.Status.SkippedLabels = skippedLabels
-
-	if len(skippedLabels) > 0 {
-		r.setCondition(namespaceLabel, "LabelsSkipped", metav1.ConditionTrue, "ProtectedLabelsHandled", "Some labels were skipped because they are protected.")
-	} else {
-		r.setCondition(namespaceLabel, "LabelsSkipped", metav1.ConditionFalse, "ProtectedLabelsHandled", "All labels were applied successfully; no protected labels were skipped.")
+// processAnnotations plans spec.annotations the way processLabels plans labels: keys protected by
+// PROTECTED_ANNOTATIONS are skipped, and keys the namespace already carries from someone else are duplicates.
+func (r *NamespacelabelReconciler) processAnnotations(namespace *corev1.Namespace, namespaceLabel *labelsv1alpha1.Namespacelabel, protectedRules []labels.ProtectedRule) *annotationPlan {
+	plan := &annotationPlan{
+		updated:    make(map[string]string),
+		skipped:    make(map[string]string),
+		duplicates: make(map[string]string),
 	}
+	protected := labels.NewProtectedIndex(protectedRules)
 
-	if len(duplicateLabels) > 0 {
-		r.setCondition(namespaceLabel, "DuplicateLabels", metav1.ConditionTrue, "DuplicateLabelsHandled", "Some labels were not applied because they are duplicates.")
-	} else {
-		r.setCondition(namespaceLabel, "DuplicateLabels", metav1.ConditionFalse, "DuplicateLabelsHandled", "All labels were unique and applied successfully.")
+	keys := make([]string, 0, len(namespaceLabel.Spec.Annotations))
+	for key := range namespaceLabel.Spec.Annotations {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	r.setCondition(namespaceLabel, "LabelsApplied", metav1.ConditionTrue, "LabelsReconciled", "Labels reconciled successfully.")
+	for _, key := range keys {
+		value := namespaceLabel.Spec.Annotations[key]
+		_, applied := namespaceLabel.Status.AppliedAnnotations[key]
+		switch {
+		case protected.IsProtected(namespace, key):
+			r.Log.Info("Skipping protected annotation", "key", key, "value", value)
+			plan.skipped[key] = value
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "ProtectedAnnotationSkipped", fmt.Sprintf("Annotation %s=%s is protected and was not applied", key, eventValue(namespaceLabel, value)))
 
-	if err := r.Status().Update(ctx, namespaceLabel); err != nil {
-		return fmt.Errorf("failed to update Namespacelabel status: %w", err)
+		case namespace.Annotations[key] != "" && !applied:
+			r.Log.Info("Skipping duplicate annotation", "key", key, "value", value)
+			plan.duplicates[key] = value
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "DuplicateAnnotationSkipped", fmt.Sprintf("Annotation %s=%s already exists with value %s", key, eventValue(namespaceLabel, value), eventValue(namespaceLabel, namespace.Annotations[key])))
+
+		default:
+			plan.updated[key] = value
+		}
 	}
-	return nil
+	return plan
 }
 
-func (r *NamespacelabelReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&labelsv1alpha1.Namespacelabel{}).
-		Watches(&corev1.Namespace{},
-			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsFromNamespace),
-		).
-		Complete(r)
+// applyAnnotations writes the planned annotations to the namespace. It reports whether any of them changed.
+func (r *NamespacelabelReconciler) applyAnnotations(namespace *corev1.Namespace, plan *annotationPlan) bool {
+	if plan == nil {
+		return false
+	}
+	var changed []string
+	for key, value := range plan.updated {
+		if current, ok := namespace.Annotations[key]; ok && current == value {
+			continue
+		}
+		if namespace.Annotations == nil {
+			namespace.Annotations = make(map[string]string)
+		}
+		namespace.Annotations[key] = value
+		changed = append(changed, key)
+	}
+	if len(changed) > 0 {
+		sort.Strings(changed)
+		r.Log.Info("Applied annotations", "namespace", namespace.Name, "keys", changed)
+	}
+	return len(changed) > 0
 }
 
-// enqueueRequestsFromNamespace triggers reconciliation for related Namespacelabel resources when a Namespace changes.
-// enqueueRequestsFromNamespace reconciles the Namespacelabel when the associated Namespace changes.
-func (r *NamespacelabelReconciler) enqueueRequestsFromNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
-	ns, ok := namespace.(*corev1.Namespace)
-	if !ok {
-		r.Log.Error(nil, "Failed to cast object to Namespace", "object", namespace)
-		return []reconcile.Request{}
+// enforceSizeBudget moves labels that would push the combined size of the applied keys and values past
+// MAX_TOTAL_LABEL_BYTES from the plan's updates to its skipped labels. Keys are counted in application order,
+// and once the budget is exceeded every later key is skipped as well.
+func (r *NamespacelabelReconciler) enforceSizeBudget(namespaceLabel *labelsv1alpha1.Namespacelabel, plan *labelPlan, keys []string) {
+	budgetEnv := os.Getenv(MaxTotalLabelBytesEnv)
+	if budgetEnv == "" {
+		return
 	}
-
-	namespaceLabelList := &labelsv1alpha1.NamespacelabelList{}
-	listOps := &client.ListOptions{
-		Namespace: ns.Name,
+	budget, err := strconv.Atoi(budgetEnv)
+	if err != nil || budget < 0 {
+		r.Log.Error(err, "Ignoring invalid label size budget", "env", MaxTotalLabelBytesEnv, "value", budgetEnv)
+		return
 	}
-	if err := r.List(ctx, namespaceLabelList, listOps); err != nil {
-		r.Log.Error(err, "Failed to list Namespacelabel resources", "Namespace", ns.Name)
-		return []reconcile.Request{}
+
+	total := 0
+	exceeded := false
+	for _, key := range keys {
+		value, ok := plan.updated[key]
+		if !ok {
+			continue
+		}
+		total += len(key) + len(value)
+		if !exceeded && total <= budget {
+			continue
+		}
+		exceeded = true
+		r.Log.Info("Skipping label over the size budget", "key", key, "budget", budget)
+		delete(plan.updated, key)
+		plan.skip(key, value, labelsv1alpha1.SkipReasonSizeBudgetExceeded)
+		r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "SizeBudgetExceeded",
+			fmt.Sprintf("Label %s=%s was not applied because it exceeds the %d byte label size budget", key, eventValue(namespaceLabel, value), budget))
 	}
+}
 
-	requests := make([]reconcile.Request, 0, len(namespaceLabelList.Items))
-	for _, item := range namespaceLabelList.Items {
-		requests = append(requests, reconcile.Request{
-			NamespacedName: types.NamespacedName{
+// dryRunPlan is the JSON form of a labelPlan written to the DryRunPlanAnnotation.
+type dryRunPlan struct {
+	// Apply holds the labels that would be written because the namespace lacks them or has another value.
+	Apply map[string]string `json:"apply,omitempty"`
+	// Unchanged holds the keys that would be written but already have the desired value.
+	Unchanged []string `json:"unchanged,omitempty"`
+	// Skipped maps each skipped key to the reason it would be skipped.
+	Skipped map[string]labelsv1alpha1.SkipReason `json:"skipped,omitempty"`
+	// Duplicates holds the labels that would not be applied because the namespace already has the key.
+	Duplicates map[string]string `json:"duplicates,omitempty"`
+}
+
+// writeDryRunPlan compares the plan with the namespace and records the result in the DryRunPlanAnnotation,
+// without writing to the namespace.
+func (r *NamespacelabelReconciler) writeDryRunPlan(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, namespace *corev1.Namespace, plan *labelPlan) error {
+	result := dryRunPlan{
+		Apply:      make(map[string]string),
+		Skipped:    plan.skipReasons,
+		Duplicates: plan.duplicates,
+	}
+	for key, value := range plan.updated {
+		if current, ok := namespace.Labels[key]; ok && current == value {
+			result.Unchanged = append(result.Unchanged, key)
+			continue
+		}
+		result.Apply[key] = value
+	}
+	sort.Strings(result.Unchanged)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode dry-run plan: %w", err)
+	}
+	if namespaceLabel.Annotations[labels.DryRunPlanAnnotation] == string(data) {
+		return nil
+	}
+	if namespaceLabel.Annotations == nil {
+		namespaceLabel.Annotations = make(map[string]string)
+	}
+	namespaceLabel.Annotations[labels.DryRunPlanAnnotation] = string(data)
+	r.Log.Info("Recording dry-run plan", "namespace", namespaceLabel.Namespace, "plan", string(data))
+	if err := r.Update(ctx, namespaceLabel); err != nil {
+		return fmt.Errorf("failed to record dry-run plan: %w", err)
+	}
+	return nil
+}
+
+// aggregateManagedLabels records on the namespace the labels every Namespacelabel targeting it applied, and their
+// owners, when AggregateManagedLabelsEnv is set. The given Namespacelabel's in-memory status is used over the listed one,
+// which may not reflect the write just made.
+func (r *NamespacelabelReconciler) aggregateManagedLabels(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, namespaceName string) error {
+	if os.Getenv(AggregateManagedLabelsEnv) != "true" {
+		return nil
+	}
+
+	namespaceLabelList := &labelsv1alpha1.NamespacelabelList{}
+	if err := r.List(ctx, namespaceLabelList); err != nil {
+		return fmt.Errorf("failed to list Namespacelabels to aggregate managed labels: %w", err)
+	}
+	for i := range namespaceLabelList.Items {
+		if client.ObjectKeyFromObject(&namespaceLabelList.Items[i]) == client.ObjectKeyFromObject(namespaceLabel) {
+			namespaceLabelList.Items[i] = *namespaceLabel
+		}
+	}
+	managed := labels.Aggregate(namespaceLabelList.Items, namespaceName)
+
+	var namespace corev1.Namespace
+	if err := r.apiReader().Get(ctx, types.NamespacedName{Name: namespaceName}, &namespace); err != nil {
+		return client.IgnoreNotFound(fmt.Errorf("failed to get namespace %s to aggregate managed labels: %w", namespaceName, err))
+	}
+	data, err := json.Marshal(managed)
+	if err != nil {
+		return fmt.Errorf("failed to encode managed labels: %w", err)
+	}
+	original := namespace.DeepCopy()
+	current, annotated := namespace.Annotations[labels.ManagedLabelsAnnotation]
+	switch {
+	case len(managed) == 0 && !annotated, len(managed) > 0 && current == string(data):
+		return nil
+	case len(managed) == 0:
+		delete(namespace.Annotations, labels.ManagedLabelsAnnotation)
+	default:
+		if namespace.Annotations == nil {
+			namespace.Annotations = make(map[string]string)
+		}
+		namespace.Annotations[labels.ManagedLabelsAnnotation] = string(data)
+	}
+	if err := r.Patch(ctx, &namespace, client.MergeFrom(original), client.FieldOwner(r.fieldManager())); err != nil {
+		return fmt.Errorf("failed to record managed labels on namespace %s: %w", namespaceName, err)
+	}
+	return nil
+}
+
+// updateSummary refreshes the NamespacelabelSummary singleton with every namespace carrying applied labels, creating it
+// when missing. As in aggregateManagedLabels, the given Namespacelabel's in-memory status is used over the listed one.
+// The summary is informational, so failures are logged rather than failing the reconcile; the next reconcile retries.
+func (r *NamespacelabelReconciler) updateSummary(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) {
+	namespaceLabelList := &labelsv1alpha1.NamespacelabelList{}
+	if err := r.List(ctx, namespaceLabelList); err != nil {
+		r.Log.Error(err, "Failed to list Namespacelabels to update the summary")
+		return
+	}
+	for i := range namespaceLabelList.Items {
+		if client.ObjectKeyFromObject(&namespaceLabelList.Items[i]) == client.ObjectKeyFromObject(namespaceLabel) {
+			namespaceLabelList.Items[i] = *namespaceLabel
+		}
+	}
+	status := summarize(namespaceLabelList.Items)
+
+	summary := &labelsv1alpha1.NamespacelabelSummary{}
+	err := r.Get(ctx, types.NamespacedName{Name: labelsv1alpha1.SummaryName}, summary)
+	switch {
+	case apierrors.IsNotFound(err):
+		summary = &labelsv1alpha1.NamespacelabelSummary{ObjectMeta: metav1.ObjectMeta{Name: labelsv1alpha1.SummaryName}}
+		if err := r.Create(ctx, summary); err != nil {
+			r.Log.Error(err, "Failed to create the Namespacelabel summary")
+			return
+		}
+	case err != nil:
+		r.Log.Error(err, "Failed to get the Namespacelabel summary")
+		return
+	case equality.Semantic.DeepEqual(summary.Status, status):
+		return
+	}
+	summary.Status = status
+	if err := r.Status().Update(ctx, summary); err != nil {
+		r.Log.Error(err, "Failed to update the Namespacelabel summary")
+	}
+}
+
+// summarize counts, per target namespace, the labels applied by the given Namespacelabels and how many of them applied
+// any. Namespacelabels being deleted are left out.
+func summarize(namespaceLabels []labelsv1alpha1.Namespacelabel) labelsv1alpha1.NamespacelabelSummaryStatus {
+	byName := make(map[string]*labelsv1alpha1.ManagedNamespace)
+	var status labelsv1alpha1.NamespacelabelSummaryStatus
+	for i := range namespaceLabels {
+		namespaceLabel := &namespaceLabels[i]
+		if len(namespaceLabel.Status.AppliedLabels) == 0 || !namespaceLabel.DeletionTimestamp.IsZero() {
+			continue
+		}
+		managed, ok := byName[namespaceLabel.Status.TargetNamespace]
+		if !ok {
+			managed = &labelsv1alpha1.ManagedNamespace{Name: namespaceLabel.Status.TargetNamespace}
+			byName[managed.Name] = managed
+		}
+		managed.AppliedLabels += int32(len(namespaceLabel.Status.AppliedLabels))
+		managed.Namespacelabels++
+		status.TotalAppliedLabels += int32(len(namespaceLabel.Status.AppliedLabels))
+	}
+	for _, managed := range byName {
+		status.Namespaces = append(status.Namespaces, *managed)
+	}
+	sort.Slice(status.Namespaces, func(i, j int) bool { return status.Namespaces[i].Name < status.Namespaces[j].Name })
+	return status
+}
+
+// printedPlan is the JSON line printed for each plan when PrintPlansEnv is set.
+type printedPlan struct {
+	Time           time.Time    `json:"time"`
+	Namespacelabel string       `json:"namespacelabel"`
+	Namespace      string       `json:"namespace"`
+	DryRun         bool         `json:"dryRun"`
+	Actions        []planAction `json:"actions"`
+}
+
+// planAction is what a plan does with one desired label.
+type planAction struct {
+	Key string `json:"key"`
+	// Action is "apply", "unchanged", "skip" or "duplicate".
+	Action string `json:"action"`
+	Value  string `json:"value"`
+	// Reason says why a skipped label is skipped.
+	Reason labelsv1alpha1.SkipReason `json:"reason,omitempty"`
+}
+
+// printPlan prints the plan to stdout as a single JSON line when PrintPlansEnv is set.
+func (r *NamespacelabelReconciler) printPlan(namespaceLabel *labelsv1alpha1.Namespacelabel, namespace *corev1.Namespace, plan *labelPlan) {
+	if os.Getenv(PrintPlansEnv) != "true" {
+		return
+	}
+
+	printed := printedPlan{
+		Time:           r.now().UTC(),
+		Namespacelabel: client.ObjectKeyFromObject(namespaceLabel).String(),
+		Namespace:      namespace.Name,
+		DryRun:         isDryRun(namespaceLabel),
+		Actions:        []planAction{},
+	}
+	for key, value := range plan.updated {
+		action := "apply"
+		if current, ok := namespace.Labels[key]; ok && current == value {
+			action = "unchanged"
+		}
+		printed.Actions = append(printed.Actions, planAction{Key: key, Action: action, Value: value})
+	}
+	for key, value := range plan.skipped {
+		printed.Actions = append(printed.Actions, planAction{Key: key, Action: "skip", Value: value, Reason: plan.skipReasons[key]})
+	}
+	for key, value := range plan.duplicates {
+		printed.Actions = append(printed.Actions, planAction{Key: key, Action: "duplicate", Value: value})
+	}
+	sort.Slice(printed.Actions, func(i, j int) bool { return printed.Actions[i].Key < printed.Actions[j].Key })
+
+	line, err := json.Marshal(printed)
+	if err != nil {
+		r.Log.Error(err, "Failed to encode plan", "namespaceLabel", namespaceLabel.Name)
+		return
+	}
+	if _, err := os.Stdout.Write(append(line, '\n')); err != nil {
+		r.Log.Error(err, "Failed to print plan", "namespaceLabel", namespaceLabel.Name)
+	}
+}
+
+// isDryRun reports whether the Namespacelabel's plan is only recorded, either because it sets spec.dryRun or because
+// the operator runs in shadow mode.
+func isDryRun(namespaceLabel *labelsv1alpha1.Namespacelabel) bool {
+	return namespaceLabel.Spec.DryRun || shadowMode()
+}
+
+// shadowMode reports whether ShadowModeEnv is set.
+func shadowMode() bool {
+	return os.Getenv(ShadowModeEnv) == "true"
+}
+
+// clearDryRunPlan removes a DryRunPlanAnnotation left over from when dry-run was enabled.
+func (r *NamespacelabelReconciler) clearDryRunPlan(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	if isDryRun(namespaceLabel) {
+		return nil
+	}
+	if _, ok := namespaceLabel.Annotations[labels.DryRunPlanAnnotation]; !ok {
+		return nil
+	}
+	delete(namespaceLabel.Annotations, labels.DryRunPlanAnnotation)
+	if err := r.Update(ctx, namespaceLabel); err != nil {
+		return fmt.Errorf("failed to clear dry-run plan: %w", err)
+	}
+	return nil
+}
+
+// orderedKeys returns the desired label keys in application order: keys listed in spec.labelOrder first,
+// then the remaining keys sorted lexically.
+func orderedKeys(desired map[string]string, labelOrder []string) []string {
+	keys := make([]string, 0, len(desired))
+	seen := make(map[string]bool, len(desired))
+	for _, key := range labelOrder {
+		if _, ok := desired[key]; ok && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+
+	rest := make([]string, 0, len(desired)-len(keys))
+	for key := range desired {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// ensureTeamOwner fetches the team named by spec.teamRef and makes it an owner of the Namespacelabel,
+// so the Namespacelabel is garbage-collected with the team. It returns nil when no team is referenced.
+func (r *NamespacelabelReconciler) ensureTeamOwner(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) (*unstructured.Unstructured, error) {
+	teamRef := namespaceLabel.Spec.TeamRef
+	if teamRef == nil {
+		return nil, nil
+	}
+
+	team := &unstructured.Unstructured{}
+	team.SetAPIVersion(teamRef.APIVersion)
+	team.SetKind(teamRef.Kind)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespaceLabel.Namespace, Name: teamRef.Name}, team); err != nil {
+		return nil, fmt.Errorf("failed to get team %s %s: %w", teamRef.Kind, teamRef.Name, err)
+	}
+
+	for _, owner := range namespaceLabel.OwnerReferences {
+		if owner.UID == team.GetUID() {
+			return team, nil
+		}
+	}
+	if err := controllerutil.SetOwnerReference(team, namespaceLabel, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set team owner reference: %w", err)
+	}
+	if err := r.Update(ctx, namespaceLabel); err != nil {
+		return nil, fmt.Errorf("failed to add team owner reference: %w", err)
+	}
+	r.Log.Info("Added team owner reference", "namespace", namespaceLabel.Namespace, "kind", teamRef.Kind, "team", teamRef.Name)
+	return team, nil
+}
+
+// fetchLabelsFrom reads the labels from the ConfigMap named by spec.labelsFrom.
+// Entries that are not valid labels are skipped with a warning event rather than failing the whole apply.
+func (r *NamespacelabelReconciler) fetchLabelsFrom(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) (map[string]string, error) {
+	if namespaceLabel.Spec.LabelsFrom == nil {
+		return nil, nil
+	}
+
+	var configMap corev1.ConfigMap
+	key := types.NamespacedName{Namespace: namespaceLabel.Namespace, Name: namespaceLabel.Spec.LabelsFrom.Name}
+	if err := r.Get(ctx, key, &configMap); err != nil {
+		return nil, fmt.Errorf("failed to get labels ConfigMap %s: %w", key.Name, err)
+	}
+
+	configMapLabels := make(map[string]string, len(configMap.Data))
+	for labelKey, value := range configMap.Data {
+		if errs := append(validation.IsQualifiedName(labelKey), validation.IsValidLabelValue(value)...); len(errs) > 0 {
+			r.Log.Info("Skipping invalid label from ConfigMap", "configMap", key.Name, "key", labelKey, "errors", errs)
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "InvalidLabelFromConfigMap",
+				fmt.Sprintf("Entry %s in ConfigMap %s is not a valid label and was skipped: %s", labelKey, key.Name, strings.Join(errs, "; ")))
+			continue
+		}
+		configMapLabels[labelKey] = value
+	}
+	return configMapLabels, nil
+}
+
+// renderLabels returns spec.labels with template values rendered against the variables ConfigMap when
+// spec.enableTemplating is set, and spec.labels unchanged otherwise. A value that fails to render, or renders to an
+// invalid label value, is left out and its error returned by key, for skipUnrendered to report.
+func (r *NamespacelabelReconciler) renderLabels(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) (map[string]string, map[string]error, error) {
+	if !namespaceLabel.Spec.EnableTemplating {
+		return namespaceLabel.Spec.Labels, nil, nil
+	}
+	templateVars, err := vars.Load(ctx, r.Client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rendered := make(map[string]string, len(namespaceLabel.Spec.Labels))
+	renderErrs := make(map[string]error)
+	for key, value := range namespaceLabel.Spec.Labels {
+		renderedValue, err := labels.Render(value, templateVars)
+		if err != nil {
+			renderErrs[key] = fmt.Errorf("failed to render label %s: %w", key, err)
+			continue
+		}
+		if errs := validation.IsValidLabelValue(renderedValue); len(errs) > 0 {
+			renderErrs[key] = fmt.Errorf("label %s rendered to invalid value %q: %s", key, renderedValue, strings.Join(errs, "; "))
+			continue
+		}
+		rendered[key] = renderedValue
+	}
+	return rendered, renderErrs, nil
+}
+
+// skipUnrendered records the spec.labels keys whose template failed to render, or rendered to an invalid label value,
+// as skipped, so one bad template does not hold back the other labels.
+func (r *NamespacelabelReconciler) skipUnrendered(namespaceLabel *labelsv1alpha1.Namespacelabel, plan *labelPlan, renderErrs map[string]error) {
+	keys := make([]string, 0, len(renderErrs))
+	for key := range renderErrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		r.Log.Info("Skipping label whose template did not render", "key", key, "error", renderErrs[key].Error())
+		plan.skip(key, namespaceLabel.Spec.Labels[key], labelsv1alpha1.SkipReasonRenderFailed)
+		r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "RenderFailed",
+			fmt.Sprintf("Label %s was not applied: %v", key, renderErrs[key]))
+	}
+}
+
+// desiredLabels returns the labels the Namespacelabel asks for: the labels read from spec.labelsFrom overlaid with
+// spec.labels, given as specLabels with any templates rendered, plus, when spec.teamRef sets a labelKey, the team's
+// name under that key, and finally the aliases of all of them, with spec.transforms applied to the values.
+func desiredLabels(namespaceLabel *labelsv1alpha1.Namespacelabel, specLabels map[string]string, team *unstructured.Unstructured, configMapLabels map[string]string) map[string]string {
+	desired := make(map[string]string, len(configMapLabels)+len(specLabels)+1)
+	for key, value := range configMapLabels {
+		desired[key] = value
+	}
+	for key, value := range specLabels {
+		desired[key] = value
+	}
+	if team != nil && namespaceLabel.Spec.TeamRef.LabelKey != "" {
+		if _, ok := desired[namespaceLabel.Spec.TeamRef.LabelKey]; !ok {
+			desired[namespaceLabel.Spec.TeamRef.LabelKey] = team.GetName()
+		}
+	}
+	return labels.WithTransforms(labels.WithAliases(desired, namespaceLabel.Spec.Aliases), namespaceLabel.Spec.Transforms)
+}
+
+// recordApplyTimestamp stamps the namespace with the apply time when the CR asks for it and the apply changed the
+// namespace, recording the stamp in status.appliedTimestamp. Once the option is turned off, the stamp is dropped only
+// while it is still the one this CR wrote, since every Namespacelabel targeting the namespace shares the annotation.
+// It reports whether the annotation changed.
+func (r *NamespacelabelReconciler) recordApplyTimestamp(namespace *corev1.Namespace, namespaceLabel *labelsv1alpha1.Namespacelabel, changed bool) bool {
+	if !namespaceLabel.Spec.RecordApplyTimestamp {
+		owned := labels.OwnsApplyTimestamp(namespace, namespaceLabel.Status.AppliedTimestamp)
+		namespaceLabel.Status.AppliedTimestamp = ""
+		if !owned {
+			return false
+		}
+		delete(namespace.Annotations, labels.LastAppliedAnnotation)
+		return true
+	}
+	if !changed {
+		return false
+	}
+	stamp := r.now().UTC().Format(time.RFC3339)
+	namespaceLabel.Status.AppliedTimestamp = stamp
+	if namespace.Annotations[labels.LastAppliedAnnotation] == stamp {
+		return false
+	}
+	if namespace.Annotations == nil {
+		namespace.Annotations = make(map[string]string)
+	}
+	namespace.Annotations[labels.LastAppliedAnnotation] = stamp
+	return true
+}
+
+// recordSpecHash sets the Namespacelabel's spec hash label to the hash of the planned labels when spec.recordSpecHash
+// is set, and drops it once the option is turned off or nothing is planned. It reports whether the label changed.
+func recordSpecHash(namespace *corev1.Namespace, namespaceLabel *labelsv1alpha1.Namespacelabel, plan *labelPlan) bool {
+	key := labels.SpecHashLabelKey(namespaceLabel.Namespace, namespaceLabel.Name)
+	current, hasHash := namespace.Labels[key]
+	hash := ""
+	if namespaceLabel.Spec.RecordSpecHash {
+		hash = labels.SpecHash(plan.updated)
+	}
+	if hash == "" {
+		delete(namespace.Labels, key)
+		return hasHash
+	}
+	namespace.Labels[key] = hash
+	return current != hash
+}
+
+// mirrorAnnotations writes a mirror annotation for each planned label when spec.mirrorToAnnotations is set,
+// and drops the mirrors of previously applied labels that are no longer planned, or all of them once the option is
+// turned off. It reports whether any annotation changed.
+func mirrorAnnotations(namespace *corev1.Namespace, namespaceLabel *labelsv1alpha1.Namespacelabel, plan *labelPlan) bool {
+	changed := false
+	for key := range namespaceLabel.Status.AppliedLabels {
+		if _, planned := plan.updated[key]; planned && namespaceLabel.Spec.MirrorToAnnotations {
+			continue
+		}
+		annotationKey := labels.MirrorAnnotationKey(key)
+		if _, ok := namespace.Annotations[annotationKey]; ok {
+			delete(namespace.Annotations, annotationKey)
+			changed = true
+		}
+	}
+	if !namespaceLabel.Spec.MirrorToAnnotations {
+		return changed
+	}
+
+	if namespace.Annotations == nil {
+		namespace.Annotations = make(map[string]string)
+	}
+	for key, value := range plan.updated {
+		annotationKey := labels.MirrorAnnotationKey(key)
+		if current, ok := namespace.Annotations[annotationKey]; !ok || current != value {
+			namespace.Annotations[annotationKey] = value
+			changed = true
+		}
+	}
+	return changed
+}
+
+// isCleanApply reports whether the apply was fully successful: some labels are applied, every planned label landed,
+// and none was skipped or found to be a duplicate.
+func isCleanApply(plan *labelPlan, appliedLabels map[string]string, applyErr error) bool {
+	return applyErr == nil && len(appliedLabels) > 0 && len(appliedLabels) == len(plan.updated) &&
+		len(plan.skipped) == 0 && len(plan.duplicates) == 0
+}
+
+// readBackApplied returns the written labels that actually landed on the namespace.
+// The namespace must hold the API server's response to the write: another admission controller may have
+// altered or stripped some of the labels, so the plan alone can't be trusted. The response is used rather
+// than a fresh Get because the cached client may not have observed the write yet.
+func readBackApplied(namespace *corev1.Namespace, updatedLabels map[string]string) map[string]string {
+	appliedLabels := make(map[string]string)
+	for key, value := range updatedLabels {
+		if current, ok := namespace.Labels[key]; ok && current == value {
+			appliedLabels[key] = value
+		}
+	}
+	return appliedLabels
+}
+
+// readBackAppliedAnnotations is readBackApplied for the planned annotations.
+func readBackAppliedAnnotations(namespace *corev1.Namespace, plan *annotationPlan) map[string]string {
+	appliedAnnotations := make(map[string]string)
+	if plan == nil {
+		return appliedAnnotations
+	}
+	for key, value := range plan.updated {
+		if current, ok := namespace.Annotations[key]; ok && current == value {
+			appliedAnnotations[key] = value
+		}
+	}
+	return appliedAnnotations
+}
+
+// recordAnnotationStatus records the applied and skipped annotations in status.
+func recordAnnotationStatus(namespaceLabel *labelsv1alpha1.Namespacelabel, plan *annotationPlan, appliedAnnotations map[string]string) {
+	namespaceLabel.Status.AppliedAnnotations = statusValues(namespaceLabel, appliedAnnotations)
+	namespaceLabel.Status.SkippedAnnotations = nil
+	if plan != nil {
+		namespaceLabel.Status.SkippedAnnotations = statusValues(namespaceLabel, plan.skipped)
+	}
+}
+
+// wasApplied reports whether the key was applied by this Namespacelabel on a previous reconcile.
+// Such keys are owned by the CR, so finding them on the namespace is not a duplicate.
+func wasApplied(namespaceLabel *labelsv1alpha1.Namespacelabel, key string) bool {
+	_, ok := namespaceLabel.Status.AppliedLabels[key]
+	return ok
+}
+
+// statusValues returns the labels as recorded in status and notifications: unchanged, or with every value redacted when
+// spec.redactStatusValues is set.
+func statusValues(namespaceLabel *labelsv1alpha1.Namespacelabel, labelValues map[string]string) map[string]string {
+	if !namespaceLabel.Spec.RedactStatusValues {
+		return labelValues
+	}
+	return labels.Redact(labelValues)
+}
+
+// eventValue returns a label value as named in events: unchanged, or labels.RedactedValue when
+// spec.redactStatusValues is set.
+func eventValue(namespaceLabel *labelsv1alpha1.Namespacelabel, value string) string {
+	if namespaceLabel.Spec.RedactStatusValues {
+		return labels.RedactedValue
+	}
+	return value
+}
+
+// verifyChecksum compares the checksum of the namespace's current values of the applied labels against
+// status.appliedChecksum and records a ChecksumMismatch event when they differ. The labels themselves are
+// restored by the apply that follows, which reports each drifted key.
+func (r *NamespacelabelReconciler) verifyChecksum(namespace *corev1.Namespace, namespaceLabel *labelsv1alpha1.Namespacelabel) {
+	if namespaceLabel.Status.AppliedChecksum == "" {
+		return
+	}
+	current := make(map[string]string, len(namespaceLabel.Status.AppliedLabels))
+	for key, applied := range namespaceLabel.Status.AppliedLabels {
+		value, ok := namespace.Labels[key]
+		switch {
+		case !ok:
+		case labels.ValuesEqual(value, applied):
+			// Under CASE_INSENSITIVE_VALUES a change of case is not a mismatch.
+			current[key] = applied
+		default:
+			current[key] = value
+		}
+	}
+	if labels.Checksum(current) == namespaceLabel.Status.AppliedChecksum {
+		return
+	}
+	r.Log.Info("Checksum mismatch, managed labels were changed out-of-band", "namespace", namespace.Name)
+	r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "ChecksumMismatch",
+		fmt.Sprintf("Managed labels on namespace %s no longer match the applied checksum and will be restored", namespace.Name))
+}
+
+// detectDrift emits a DriftDetected event when a label this Namespacelabel applied was changed or removed
+// out-of-band. The caller restores the label afterwards.
+func (r *NamespacelabelReconciler) detectDrift(namespace *corev1.Namespace, namespaceLabel *labelsv1alpha1.Namespacelabel, key string) {
+	applied, ok := namespaceLabel.Status.AppliedLabels[key]
+	if !ok {
+		return
+	}
+
+	current, exists := namespace.Labels[key]
+	switch {
+	case !exists:
+		r.Log.Info("Drift detected, managed label was removed", "namespace", namespace.Name, "key", key)
+		r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "DriftDetected",
+			fmt.Sprintf("Label %s=%s was removed from namespace %s out-of-band and will be restored", key, eventValue(namespaceLabel, applied), namespace.Name))
+	case applied == labels.RedactedValue:
+		// A redacted status cannot tell a changed value apart; ChecksumMismatch reports it instead.
+	case current != applied:
+		r.Log.Info("Drift detected, managed label was changed", "namespace", namespace.Name, "key", key, "applied", applied, "current", current)
+		r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "DriftDetected",
+			fmt.Sprintf("Label %s was changed on namespace %s out-of-band from %s to %s and will be restored", key, namespace.Name, applied, current))
+	}
+}
+
+// rejectStrictProtected records that a strict CR asked for protected labels, without applying anything.
+// Nothing is requeued: the CR can only make progress once its spec changes.
+func (r *NamespacelabelReconciler) rejectStrictProtected(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, plan *labelPlan, protectedKeys []string) error {
+	message := fmt.Sprintf("No labels were applied because strictProtected is set and labels %v are protected.", protectedKeys)
+	r.Log.Info("Strict protected violation, skipping apply", "namespace", namespaceLabel.Namespace, "protectedKeys", protectedKeys)
+	r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "StrictProtectedViolation", message)
+
+	namespaceLabel.Status.SkippedLabels = statusValues(namespaceLabel, plan.skipped)
+	namespaceLabel.Status.SkipReasons = plan.skipReasons
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionStrictProtectedViolation, metav1.ConditionTrue, labelsv1alpha1.ReasonProtectedLabelsRequested, message)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsApplied, metav1.ConditionFalse, labelsv1alpha1.ReasonStrictProtectedViolation, message)
+
+	return r.writeStatus(ctx, namespaceLabel)
+}
+
+// untilApplyAfter returns how long to wait before spec.applyAfter is reached, or zero when labels may be applied now.
+func (r *NamespacelabelReconciler) untilApplyAfter(namespaceLabel *labelsv1alpha1.Namespacelabel) time.Duration {
+	if namespaceLabel.Spec.ApplyAfter == nil {
+		return 0
+	}
+	return namespaceLabel.Spec.ApplyAfter.Sub(r.now())
+}
+
+// nextWindowOpen returns when spec.maintenanceWindow next opens, or the zero time when there is no window
+// or it is open now.
+func (r *NamespacelabelReconciler) nextWindowOpen(namespaceLabel *labelsv1alpha1.Namespacelabel) (time.Time, error) {
+	if namespaceLabel.Spec.MaintenanceWindow == nil {
+		return time.Time{}, nil
+	}
+	maintenanceWindow, err := window.Parse(namespaceLabel.Spec.MaintenanceWindow)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid spec.maintenanceWindow: %w", err)
+	}
+	now := r.now()
+	if maintenanceWindow.Contains(now) {
+		return time.Time{}, nil
+	}
+	return maintenanceWindow.NextOpen(now), nil
+}
+
+// markOutsideWindow records that applying labels is deferred until the maintenance window opens,
+// without applying anything.
+func (r *NamespacelabelReconciler) markOutsideWindow(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, opensAt time.Time) error {
+	opens := opensAt.UTC().Format(time.RFC3339)
+	r.Log.Info("Outside the maintenance window, skipping apply", "namespace", namespaceLabel.Namespace, "opensAt", opens)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionOutsideWindow, metav1.ConditionTrue, labelsv1alpha1.ReasonOutsideMaintenanceWindow,
+		fmt.Sprintf("Labels will be applied when the maintenance window opens at %s.", opens))
+	return r.writeStatus(ctx, namespaceLabel)
+}
+
+// now returns the current time from the configured Clock, falling back to the real clock.
+func (r *NamespacelabelReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+// requiredResourceExists reports whether the resource named in spec.requires exists. It always does when
+// spec.requires is unset.
+func (r *NamespacelabelReconciler) requiredResourceExists(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) (bool, error) {
+	requires := namespaceLabel.Spec.Requires
+	if requires == nil {
+		return true, nil
+	}
+
+	resource := &unstructured.Unstructured{}
+	resource.SetAPIVersion(requires.APIVersion)
+	resource.SetKind(requires.Kind)
+	key := types.NamespacedName{Namespace: requires.Namespace, Name: requires.Name}
+	if key.Namespace == "" {
+		key.Namespace = namespaceLabel.Namespace
+	}
+	if err := r.Get(ctx, key, resource); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get required %s %s: %w", requires.Kind, key, err)
+	}
+	return true, nil
+}
+
+// markDependencyMissing records that the resource named in spec.requires does not exist yet.
+func (r *NamespacelabelReconciler) markDependencyMissing(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	requires := namespaceLabel.Spec.Requires
+	message := fmt.Sprintf("Required %s %s does not exist; no labels are applied until it does.", requires.Kind, requires.Name)
+	r.Log.Info("Required resource is missing, skipping apply", "namespace", namespaceLabel.Namespace, "kind", requires.Kind, "name", requires.Name)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionDependencyMissing, metav1.ConditionTrue, labelsv1alpha1.ReasonDependencyNotFound, message)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsApplied, metav1.ConditionFalse, labelsv1alpha1.ReasonDependencyNotFound, message)
+	return r.writeStatus(ctx, namespaceLabel)
+}
+
+// markScheduled records that applying labels is delayed until spec.applyAfter, without applying anything.
+func (r *NamespacelabelReconciler) markScheduled(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	applyAfter := namespaceLabel.Spec.ApplyAfter.UTC().Format(time.RFC3339)
+	r.Log.Info("Labels are scheduled for later, skipping apply", "namespace", namespaceLabel.Namespace, "applyAfter", applyAfter)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionScheduled, metav1.ConditionTrue, labelsv1alpha1.ReasonApplyScheduled,
+		fmt.Sprintf("Labels will be applied after %s.", applyAfter))
+	return r.writeStatus(ctx, namespaceLabel)
+}
+
+// loadProtected returns the protected label rules, from the ProtectedConfigMap when one is configured and from the
+// PROTECTED_LABELS environment variable otherwise. ConfigMap rules come from the snapshot reloadProtected stores
+// on each change to the ConfigMap; the first call loads it, and so does every call after a failed load, since the
+// failure may have been transient and no ConfigMap change would follow to trigger a reload.
+func (r *NamespacelabelReconciler) loadProtected(ctx context.Context) ([]labels.ProtectedRule, error) {
+	if r.ProtectedConfigMap.Name == "" {
+		return labels.LoadProtected(r.Log)
+	}
+	snapshot := r.protectedSnapshot.Load()
+	if snapshot == nil || snapshot.err != nil {
+		snapshot = r.reloadProtected(ctx)
+	}
+	return snapshot.rules, snapshot.err
+}
+
+// reloadProtected loads the ProtectedConfigMap into a new snapshot and swaps it in. Reconciles running meanwhile
+// keep the snapshot they already loaded, so none of them sees a partially loaded configuration.
+func (r *NamespacelabelReconciler) reloadProtected(ctx context.Context) *protectedSnapshot {
+	rules, err := labels.LoadProtectedFromConfigMap(ctx, r.Client, r.ProtectedConfigMap)
+	if err != nil {
+		r.Log.Error(err, "Failed to load the protected labels ConfigMap", "configMap", r.ProtectedConfigMap)
+	}
+	snapshot := &protectedSnapshot{rules: rules, err: err}
+	r.protectedSnapshot.Store(snapshot)
+	return snapshot
+}
+
+// isProtectedConfigMap reports whether the object is the configured ProtectedConfigMap.
+func (r *NamespacelabelReconciler) isProtectedConfigMap(object client.Object) bool {
+	return r.ProtectedConfigMap.Name != "" && r.ProtectedConfigMap == client.ObjectKeyFromObject(object)
+}
+
+// markConfigLoadFailed records that the protected labels configuration could not be loaded and returns the
+// load error, so the reconcile is retried without applying anything.
+func (r *NamespacelabelReconciler) markConfigLoadFailed(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, loadErr error) error {
+	reason := labelsv1alpha1.ReasonProtectedConfigInvalid
+	if errors.Is(loadErr, labels.ErrProtectedNotSet) || errors.Is(loadErr, labels.ErrProtectedConfigMapNotFound) {
+		reason = labelsv1alpha1.ReasonProtectedConfigMissing
+	}
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionConfigLoaded, metav1.ConditionFalse, reason,
+		fmt.Sprintf("Failed to load the protected labels configuration: %v", loadErr))
+	if err := r.writeStatus(ctx, namespaceLabel); err != nil {
+		r.Log.Error(err, "Failed to record the protected labels configuration failure", "namespaceLabel", namespaceLabel.Name)
+	}
+	return fmt.Errorf("failed to load the protected labels list: %w", loadErr)
+}
+
+// markDenied records that the target namespace is listed in the deny ConfigMap, without applying anything.
+func (r *NamespacelabelReconciler) markDenied(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, namespace *corev1.Namespace) error {
+	message := fmt.Sprintf("Namespace %s is listed in the operator's deny ConfigMap; no labels are applied.", namespace.Name)
+	r.Log.Info("Namespace is denied, skipping apply", "namespace", namespace.Name)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionNamespaceDenied, metav1.ConditionTrue, labelsv1alpha1.ReasonNamespaceDenied, message)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsApplied, metav1.ConditionFalse, labelsv1alpha1.ReasonNamespaceDenied, message)
+	return r.writeStatus(ctx, namespaceLabel)
+}
+
+// markMissingNamespace records that the Namespacelabel has no metadata.namespace, which the webhook rejects but
+// which can still reach the controller when webhooks are disabled. Nothing is applied and the finalizer is not added;
+// the reconcile is not retried, since only recreating the Namespacelabel in a namespace fixes it.
+func (r *NamespacelabelReconciler) markMissingNamespace(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	message := "Namespacelabel has no metadata.namespace; it must be created in a namespace. No labels are applied."
+	r.Log.Info("Namespacelabel has no namespace, skipping apply", "name", namespaceLabel.Name)
+	r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "MissingNamespace", message)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsApplied, metav1.ConditionFalse, labelsv1alpha1.ReasonMissingNamespace, message)
+	return r.writeStatus(ctx, namespaceLabel)
+}
+
+// markOptedOut records that the target namespace opted out of operator management, without applying anything.
+func (r *NamespacelabelReconciler) markOptedOut(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, namespace *corev1.Namespace) error {
+	message := fmt.Sprintf("Namespace %s opted out of operator management with the %s annotation; no labels are applied.", namespace.Name, labels.OptOutAnnotation)
+	r.Log.Info("Namespace opted out, skipping apply", "namespace", namespace.Name)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionOptedOut, metav1.ConditionTrue, labelsv1alpha1.ReasonNamespaceOptedOut, message)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsApplied, metav1.ConditionFalse, labelsv1alpha1.ReasonNamespaceOptedOut, message)
+	return r.writeStatus(ctx, namespaceLabel)
+}
+
+// observe reports the target namespace's current labels in status.observedLabels without applying anything.
+// It is re-run whenever the namespace changes, so the status stays a live view of the namespace.
+func (r *NamespacelabelReconciler) observe(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	targetName, err := r.resolveTarget(ctx, namespaceLabel)
+	if err != nil {
+		return err
+	}
+	if targetName == "" {
+		namespaceLabel.Status.ObservedLabels = nil
+		return r.writeStatus(ctx, namespaceLabel)
+	}
+	namespace, err := r.fetchNamespace(ctx, targetName)
+	if err != nil {
+		return err
+	}
+
+	r.Log.Info("Observe-only Namespacelabel, reporting namespace labels", "namespace", namespace.Name, "labels", len(namespace.Labels))
+	namespaceLabel.Status.ObservedLabels = maps.Clone(namespace.Labels)
+	namespaceLabel.Status.TargetNamespace = namespace.Name
+	namespaceLabel.Status.NamespaceUID = namespace.UID
+	message := fmt.Sprintf("Observing namespace %s; no labels are applied.", namespace.Name)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionObserving, metav1.ConditionTrue, labelsv1alpha1.ReasonObserveOnly, message)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsApplied, metav1.ConditionFalse, labelsv1alpha1.ReasonObserveOnly, message)
+	return r.writeStatus(ctx, namespaceLabel)
+}
+
+// canUpdateNamespace reports whether the operator may update the namespace. It always does unless
+// CheckNamespaceAccessEnv is set, in which case a SelfSubjectAccessReview decides.
+func (r *NamespacelabelReconciler) canUpdateNamespace(ctx context.Context, namespace *corev1.Namespace) (bool, error) {
+	if os.Getenv(CheckNamespaceAccessEnv) != "true" {
+		return true, nil
+	}
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:     "patch",
+				Resource: "namespaces",
+				Name:     namespace.Name,
+			},
+		},
+	}
+	if err := r.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("failed to review access to namespace %s: %w", namespace.Name, err)
+	}
+	return review.Status.Allowed, nil
+}
+
+// markNoPermission records that the operator may not update the target namespace.
+func (r *NamespacelabelReconciler) markNoPermission(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, namespace *corev1.Namespace) error {
+	message := fmt.Sprintf("The operator is not allowed to update namespace %s; no labels are applied.", namespace.Name)
+	r.Log.Info("Not allowed to update namespace, skipping apply", "namespace", namespace.Name)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionNoPermission, metav1.ConditionTrue, labelsv1alpha1.ReasonNamespaceUpdateForbidden, message)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsApplied, metav1.ConditionFalse, labelsv1alpha1.ReasonNamespaceUpdateForbidden, message)
+	return r.writeStatus(ctx, namespaceLabel)
+}
+
+// isSuspiciousEmptySpec reports whether the empty spec guard is enabled and the CR's desired labels became empty
+// while labels it applied are still recorded, without the change being confirmed.
+func (r *NamespacelabelReconciler) isSuspiciousEmptySpec(namespaceLabel *labelsv1alpha1.Namespacelabel, desired map[string]string) bool {
+	if os.Getenv(GuardEmptySpecEnv) != "true" {
+		return false
+	}
+	if len(desired) > 0 || len(namespaceLabel.Status.AppliedLabels) == 0 {
+		return false
+	}
+	return namespaceLabel.Annotations[labels.ConfirmEmptySpecAnnotation] != "true"
+}
+
+// holdEmptySpec records that an unconfirmed empty spec was held back, leaving the namespace and the
+// previously applied labels untouched. Nothing is requeued: the CR only makes progress once its spec
+// is restored or the empty spec is confirmed.
+func (r *NamespacelabelReconciler) holdEmptySpec(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	message := fmt.Sprintf("spec.labels is empty but labels were previously applied; set the %s annotation to \"true\" to confirm.", labels.ConfirmEmptySpecAnnotation)
+	r.Log.Info("Holding back suspicious empty spec", "namespace", namespaceLabel.Namespace, "name", namespaceLabel.Name)
+	r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "SuspiciousEmptySpec", message)
+
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionSuspiciousEmptySpec, metav1.ConditionTrue, labelsv1alpha1.ReasonEmptySpecNotConfirmed, message)
+	r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsApplied, metav1.ConditionFalse, labelsv1alpha1.ReasonEmptySpecNotConfirmed, message)
+
+	return r.writeStatus(ctx, namespaceLabel)
+}
+
+// The updateStatus function is updating the status to the namespacelabel reconciled object.
+// AppliedLabels is taken from the read-back namespace, and PartiallyApplied flags any gap between what was
+// written and what landed, or a failure that happened after the namespace write.
+func (r *NamespacelabelReconciler) updateStatus(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, plan *labelPlan, appliedLabels map[string]string, applyErr error) error {
+	r.recordAppliedAt(namespaceLabel, appliedLabels)
+	namespaceLabel.Status.AppliedLabels = statusValues(namespaceLabel, appliedLabels)
+	namespaceLabel.Status.AppliedChecksum = labels.Checksum(appliedLabels)
+	r.recordHistory(namespaceLabel, statusValues(namespaceLabel, appliedLabels))
+	namespaceLabel.Status.SkippedLabels = statusValues(namespaceLabel, plan.skipped)
+	namespaceLabel.Status.SkipReasons = plan.skipReasons
+	if applyErr == nil {
+		namespaceLabel.Status.ObservedGeneration = namespaceLabel.Generation
+	}
+
+	var missingKeys []string
+	for key := range plan.updated {
+		if _, ok := appliedLabels[key]; !ok {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+	sort.Strings(missingKeys)
+
+	switch {
+	case applyErr != nil:
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionPartiallyApplied, metav1.ConditionTrue, labelsv1alpha1.ReasonApplyFailed, fmt.Sprintf("Labels were written to the namespace but the apply did not complete: %v", applyErr))
+	case len(missingKeys) > 0:
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionPartiallyApplied, metav1.ConditionTrue, labelsv1alpha1.ReasonLabelsNotPresent, fmt.Sprintf("Labels %v were written but are not present on the namespace.", missingKeys))
+	default:
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionPartiallyApplied, metav1.ConditionFalse, labelsv1alpha1.ReasonAllLabelsPresent, "All written labels are present on the namespace.")
+	}
+
+	if len(plan.skipped) > 0 {
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsSkipped, metav1.ConditionTrue, labelsv1alpha1.ReasonProtectedLabelsHandled, plan.skippedMessage())
+	} else {
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsSkipped, metav1.ConditionFalse, labelsv1alpha1.ReasonProtectedLabelsHandled, "All labels were applied successfully; no protected labels were skipped.")
+	}
+
+	if len(plan.duplicates) > 0 {
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionDuplicateLabels, metav1.ConditionTrue, labelsv1alpha1.ReasonDuplicateLabelsHandled, "Some labels were not applied because they are duplicates.")
+	} else {
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionDuplicateLabels, metav1.ConditionFalse, labelsv1alpha1.ReasonDuplicateLabelsHandled, "All labels were unique and applied successfully.")
+	}
+
+	if namespaceLabel.Spec.StrictProtected {
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionStrictProtectedViolation, metav1.ConditionFalse, labelsv1alpha1.ReasonNoProtectedLabelsRequested, "No desired label is protected.")
+	} else {
+		meta.RemoveStatusCondition(&namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionStrictProtectedViolation))
+	}
+	meta.RemoveStatusCondition(&namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionSuspiciousEmptySpec))
+	meta.RemoveStatusCondition(&namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionOptedOut))
+	meta.RemoveStatusCondition(&namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionNamespaceDenied))
+	meta.RemoveStatusCondition(&namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionNoPermission))
+	meta.RemoveStatusCondition(&namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionObserving))
+	namespaceLabel.Status.ObservedLabels = nil
+
+	if namespaceLabel.Spec.NamespaceLabelSelector == nil {
+		meta.RemoveStatusCondition(&namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionTargetResolved))
+	}
+
+	var overridden []string
+	for key := range plan.overridden {
+		if _, ok := appliedLabels[key]; ok {
+			overridden = append(overridden, key)
+		}
+	}
+	if len(overridden) > 0 {
+		sort.Strings(overridden)
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionProtectedOverrideUsed, metav1.ConditionTrue, labelsv1alpha1.ReasonProtectedLabelsOverridden,
+			fmt.Sprintf("Protected labels %s were applied under the %s annotation.", strings.Join(overridden, ", "), labels.ExemptProtectedAnnotation))
+	} else {
+		meta.RemoveStatusCondition(&namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionProtectedOverrideUsed))
+	}
+
+	if len(plan.deprecated) > 0 {
+		deprecated := make([]string, 0, len(plan.deprecated))
+		for key := range plan.deprecated {
+			deprecated = append(deprecated, key)
+		}
+		sort.Strings(deprecated)
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionDeprecatedLabels, metav1.ConditionTrue, labelsv1alpha1.ReasonDeprecatedKeysUsed,
+			fmt.Sprintf("Labels %s are deprecated; migrate to their replacements.", strings.Join(deprecated, ", ")))
+	} else {
+		meta.RemoveStatusCondition(&namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionDeprecatedLabels))
+	}
+
+	if namespaceLabel.Spec.Requires != nil {
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionDependencyMissing, metav1.ConditionFalse, labelsv1alpha1.ReasonDependencyFound,
+			fmt.Sprintf("Required %s %s exists.", namespaceLabel.Spec.Requires.Kind, namespaceLabel.Spec.Requires.Name))
+	} else {
+		meta.RemoveStatusCondition(&namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionDependencyMissing))
+	}
+
+	if namespaceLabel.Spec.ApplyAfter != nil {
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionScheduled, metav1.ConditionFalse, labelsv1alpha1.ReasonApplyTimeReached, "spec.applyAfter has passed.")
+	} else {
+		meta.RemoveStatusCondition(&namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionScheduled))
+	}
+
+	if namespaceLabel.Spec.MaintenanceWindow != nil {
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionOutsideWindow, metav1.ConditionFalse, labelsv1alpha1.ReasonInsideMaintenanceWindow, "The maintenance window is open.")
+	} else {
+		meta.RemoveStatusCondition(&namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionOutsideWindow))
+	}
+
+	if isCleanApply(plan, appliedLabels, applyErr) {
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsApplied, metav1.ConditionTrue, labelsv1alpha1.ReasonCleanApply, "All labels were applied with no skips or duplicates.")
+	} else {
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionLabelsApplied, metav1.ConditionTrue, labelsv1alpha1.ReasonLabelsReconciled, "Labels reconciled successfully.")
+	}
+	r.pruneUnknownConditions(namespaceLabel)
+
+	return r.writeStatus(ctx, namespaceLabel)
+}
+
+// recordAppliedAt updates status.appliedAt for the labels now applied. It must run before status.appliedLabels is
+// replaced: a key keeps its time only if it was applied before, so a key dropped and applied again starts over.
+func (r *NamespacelabelReconciler) recordAppliedAt(namespaceLabel *labelsv1alpha1.Namespacelabel, appliedLabels map[string]string) {
+	if len(appliedLabels) == 0 {
+		namespaceLabel.Status.AppliedAt = nil
+		return
+	}
+	appliedAt := make(map[string]metav1.Time, len(appliedLabels))
+	now := metav1.NewTime(r.now())
+	for key := range appliedLabels {
+		since, ok := namespaceLabel.Status.AppliedAt[key]
+		if _, wasApplied := namespaceLabel.Status.AppliedLabels[key]; !ok || !wasApplied {
+			since = now
+		}
+		appliedAt[key] = since
+	}
+	namespaceLabel.Status.AppliedAt = appliedAt
+}
+
+// recordHistory appends the applied labels to status.history when they differ from the latest entry, dropping the
+// oldest entries beyond STATUS_HISTORY_LIMIT.
+func (r *NamespacelabelReconciler) recordHistory(namespaceLabel *labelsv1alpha1.Namespacelabel, appliedLabels map[string]string) {
+	limit := defaultStatusHistoryLimit
+	if limitEnv := os.Getenv(StatusHistoryLimitEnv); limitEnv != "" {
+		parsed, err := strconv.Atoi(limitEnv)
+		if err != nil || parsed < 0 {
+			r.Log.Error(err, "Ignoring invalid status history limit", "env", StatusHistoryLimitEnv, "value", limitEnv)
+		} else {
+			limit = parsed
+		}
+	}
+
+	history := namespaceLabel.Status.History
+	var latest map[string]string
+	if len(history) > 0 {
+		latest = history[len(history)-1].Labels
+	}
+	if labels.Checksum(latest) != labels.Checksum(appliedLabels) {
+		history = append(history, labelsv1alpha1.HistoryEntry{Time: metav1.NewTime(r.now()), Labels: appliedLabels})
+	}
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	if len(history) == 0 {
+		history = nil
+	}
+	namespaceLabel.Status.History = history
+}
+
+// pruneUnknownConditions removes conditions whose type is not in KnownConditionTypes,
+// such as ones reported by features that have since been removed.
+func (r *NamespacelabelReconciler) pruneUnknownConditions(namespaceLabel *labelsv1alpha1.Namespacelabel) {
+	known := make(map[string]bool, len(labelsv1alpha1.KnownConditionTypes))
+	for _, conditionType := range labelsv1alpha1.KnownConditionTypes {
+		known[string(conditionType)] = true
+	}
+
+	conditions := namespaceLabel.Status.Conditions[:0]
+	for _, condition := range namespaceLabel.Status.Conditions {
+		if !known[condition.Type] {
+			r.Log.Info("Pruning unknown condition", "type", condition.Type)
+			continue
+		}
+		conditions = append(conditions, condition)
+	}
+	namespaceLabel.Status.Conditions = conditions
+}
+
+// reportReconcileError records the outcome of a reconcile in the ReconcileError condition, so that the latest failure
+// shows on the Namespacelabel and not only in the logs. A success only writes status when it clears a reported failure.
+// Only the outcome is patched, so status the failed reconcile computed but did not write is left unwritten.
+// Failures of the same generation are counted, and once MAX_RECONCILE_FAILURES is reached the Namespacelabel enters the
+// Failed phase and the error is swallowed, so it is no longer requeued. It returns the error to hand to the controller.
+func (r *NamespacelabelReconciler) reportReconcileError(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, reconcileErr error) error {
+	if reconcileErr == nil && (!namespaceLabel.DeletionTimestamp.IsZero() ||
+		!meta.IsStatusConditionTrue(namespaceLabel.Status.Conditions, string(labelsv1alpha1.ConditionReconcileError)) &&
+			namespaceLabel.Status.ConsecutiveFailures == 0 && namespaceLabel.Status.Phase == "") {
+		return nil
+	}
+
+	original := namespaceLabel.DeepCopy()
+	returnErr := reconcileErr
+	if reconcileErr != nil {
+		if namespaceLabel.Status.FailedGeneration != namespaceLabel.Generation {
+			namespaceLabel.Status.FailedGeneration = namespaceLabel.Generation
+			namespaceLabel.Status.ConsecutiveFailures = 0
+			namespaceLabel.Status.Phase = ""
+		}
+		namespaceLabel.Status.ConsecutiveFailures++
+		if limit := r.maxReconcileFailures(); limit > 0 && int(namespaceLabel.Status.ConsecutiveFailures) >= limit && namespaceLabel.DeletionTimestamp.IsZero() {
+			r.Log.Info("Giving up on Namespacelabel after consecutive failures", "namespaceLabel", namespaceLabel.Name, "failures", namespaceLabel.Status.ConsecutiveFailures)
+			r.Recorder.Event(namespaceLabel, corev1.EventTypeWarning, "RetriesExhausted",
+				fmt.Sprintf("Reconcile failed %d times in a row and will not be retried until the spec changes: %v", namespaceLabel.Status.ConsecutiveFailures, reconcileErr))
+			namespaceLabel.Status.Phase = labelsv1alpha1.PhaseFailed
+			r.setCondition(namespaceLabel, labelsv1alpha1.ConditionReconcileError, metav1.ConditionTrue, labelsv1alpha1.ReasonRetriesExhausted, reconcileErr.Error())
+			returnErr = nil
+		} else {
+			r.setCondition(namespaceLabel, labelsv1alpha1.ConditionReconcileError, metav1.ConditionTrue, labelsv1alpha1.ReasonReconcileFailed, reconcileErr.Error())
+		}
+	} else {
+		namespaceLabel.Status.Phase = ""
+		namespaceLabel.Status.ConsecutiveFailures = 0
+		namespaceLabel.Status.FailedGeneration = 0
+		r.setCondition(namespaceLabel, labelsv1alpha1.ConditionReconcileError, metav1.ConditionFalse, labelsv1alpha1.ReasonReconcileSucceeded, "The last reconcile succeeded.")
+	}
+	namespaceLabel.Status.ActiveConditions = activeConditions(namespaceLabel.Status.Conditions)
+	if err := r.Status().Patch(ctx, namespaceLabel, client.MergeFrom(original)); client.IgnoreNotFound(err) != nil {
+		r.Log.Error(err, "Failed to record the reconcile outcome", "namespaceLabel", namespaceLabel.Name)
+	}
+	return returnErr
+}
+
+// maxReconcileFailures returns the bound set by MaxReconcileFailuresEnv, or 0 when reconciles are retried forever.
+func (r *NamespacelabelReconciler) maxReconcileFailures() int {
+	limitEnv := os.Getenv(MaxReconcileFailuresEnv)
+	if limitEnv == "" {
+		return 0
+	}
+	limit, err := strconv.Atoi(limitEnv)
+	if err != nil || limit < 0 {
+		r.Log.Error(err, "Ignoring invalid reconcile failure limit", "env", MaxReconcileFailuresEnv, "value", limitEnv)
+		return 0
+	}
+	return limit
+}
+
+// stickyGracePeriod returns the grace period configured in StickyGracePeriodEnv, or 0 when sticky mode is off.
+func (r *NamespacelabelReconciler) stickyGracePeriod() time.Duration {
+	periodEnv := os.Getenv(StickyGracePeriodEnv)
+	if periodEnv == "" {
+		return 0
+	}
+	period, err := time.ParseDuration(periodEnv)
+	if err != nil || period < 0 {
+		r.Log.Error(err, "Ignoring invalid sticky grace period", "env", StickyGracePeriodEnv, "value", periodEnv)
+		return 0
+	}
+	return period
+}
+
+// keepSticky leaves the labels of a deleted Namespacelabel on its namespace in sticky mode, recording them in
+// labels.StickyAnnotation for a recreated Namespacelabel to adopt. The annotation makes the namespace watch enqueue a
+// sticky request for the namespace, which removes the labels once the grace period ends. Only the labels it applied are kept: its annotations, apply timestamp and spec hash are removed right away.
+// It reports whether the labels were kept; when they were not, the regular cleanup applies. Namespacelabels
+// propagating their labels are always cleaned up, since the propagated copies would outlive the grace period.
+func (r *NamespacelabelReconciler) keepSticky(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) (bool, error) {
+	grace := r.stickyGracePeriod()
+	if grace == 0 || len(namespaceLabel.Status.AppliedLabels) == 0 || len(namespaceLabel.Spec.PropagateTo) > 0 {
+		return false, nil
+	}
+	var namespace corev1.Namespace
+	if err := r.apiReader().Get(ctx, client.ObjectKey{Name: finalizer.TargetNamespace(namespaceLabel)}, &namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to retrieve namespace: %w", err)
+	}
+	denied, err := denylist.Load(ctx, r.apiReader())
+	if err != nil {
+		return false, err
+	}
+	if !namespace.DeletionTimestamp.IsZero() || denied[namespace.Name] || labels.IsOptedOut(&namespace) {
+		// The regular cleanup leaves such namespaces alone, too.
+		return false, nil
+	}
+
+	kept := make(map[string]string)
+	for key := range namespaceLabel.Status.AppliedLabels {
+		if value, ok := namespace.Labels[key]; ok {
+			kept[key] = value
+		}
+	}
+	if len(kept) == 0 {
+		return false, nil
+	}
+
+	original := namespace.DeepCopy()
+	records, err := labels.StickyRecords(&namespace)
+	if err != nil {
+		r.Log.Error(err, "Replacing malformed sticky records", "namespace", namespace.Name)
+	}
+	identity := client.ObjectKeyFromObject(namespaceLabel).String()
+	records[identity] = labels.StickyRecord{Labels: kept, Expires: metav1.NewTime(r.now().Add(grace))}
+	if err := labels.SetStickyRecords(&namespace, records); err != nil {
+		return false, err
+	}
+	for key := range namespaceLabel.Status.AppliedAnnotations {
+		delete(namespace.Annotations, key)
+	}
+	if labels.OwnsApplyTimestamp(&namespace, namespaceLabel.Status.AppliedTimestamp) {
+		delete(namespace.Annotations, labels.LastAppliedAnnotation)
+	}
+	delete(namespace.Labels, labels.SpecHashLabelKey(namespaceLabel.Namespace, namespaceLabel.Name))
+	if err := r.Patch(ctx, &namespace, client.MergeFrom(original), client.FieldOwner(r.fieldManager())); err != nil {
+		return false, fmt.Errorf("failed to patch namespace: %w", err)
+	}
+
+	r.Log.Info("Sticky mode, keeping labels of the deleted Namespacelabel", "namespace", namespace.Name, "namespaceLabel", identity, "gracePeriod", grace)
+	r.Recorder.Event(namespaceLabel, corev1.EventTypeNormal, "StickyLabelsKept",
+		fmt.Sprintf("Kept %d labels on namespace %s for %s in case the Namespacelabel is recreated", len(kept), namespace.Name, grace))
+	return true, nil
+}
+
+// adoptSticky takes over the labels a deleted predecessor of the Namespacelabel left on the namespace in sticky mode:
+// those still desired and unchanged are recorded as applied by this Namespacelabel, so they are neither duplicates
+// nor written again, and the others are removed. Expired records of any Namespacelabel are cleaned up on the way.
+func (r *NamespacelabelReconciler) adoptSticky(ctx context.Context, namespace *corev1.Namespace, namespaceLabel *labelsv1alpha1.Namespacelabel, desired map[string]string) error {
+	if _, ok := namespace.Annotations[labels.StickyAnnotation]; !ok || shadowMode() || isDryRun(namespaceLabel) {
+		return nil
+	}
+	original := namespace.DeepCopy()
+	records, err := labels.StickyRecords(namespace)
+	if err != nil {
+		r.Log.Error(err, "Dropping malformed sticky records", "namespace", namespace.Name)
+	}
+
+	changed := err != nil
+	identity := client.ObjectKeyFromObject(namespaceLabel).String()
+	if record, ok := records[identity]; ok && r.now().Before(record.Expires.Time) {
+		changed = true
+		delete(records, identity)
+		if namespaceLabel.Status.AppliedLabels == nil {
+			namespaceLabel.Status.AppliedLabels = make(map[string]string)
+		}
+		adopted := 0
+		for key, value := range record.Labels {
+			if current, exists := namespace.Labels[key]; !exists || current != value {
+				// Changed since the deletion, so no longer ours to adopt or remove.
+				continue
+			}
+			if _, ok := desired[key]; !ok {
+				delete(namespace.Labels, key)
+				delete(namespace.Annotations, labels.MirrorAnnotationKey(key))
+				continue
+			}
+			namespaceLabel.Status.AppliedLabels[key] = value
+			adopted++
+		}
+		r.Log.Info("Adopted labels kept in sticky mode", "namespace", namespace.Name, "namespaceLabel", identity, "adopted", adopted)
+		r.Recorder.Event(namespaceLabel, corev1.EventTypeNormal, "StickyLabelsAdopted",
+			fmt.Sprintf("Adopted %d labels kept on namespace %s since a Namespacelabel with the same name was deleted", adopted, namespace.Name))
+	}
+	if expireStickyRecords(namespace, records, r.now(), r.Log) > 0 {
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := labels.SetStickyRecords(namespace, records); err != nil {
+		return err
+	}
+	if err := r.Patch(ctx, namespace, client.MergeFrom(original), client.FieldOwner(r.fieldManager())); err != nil {
+		return fmt.Errorf("failed to patch namespace: %w", err)
+	}
+	return nil
+}
+
+// stickyRequest returns the request reconciling the sticky records of the namespace. Namespacelabels are namespaced,
+// so a request without a namespace never names one, and Reconcile routes it to reconcileSticky.
+func stickyRequest(namespaceName string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Name: namespaceName}}
+}
+
+// reconcileSticky removes the labels of the namespace's sticky records whose grace period ended, and requeues itself
+// for when the next remaining record expires. Expiry is driven by reconciles rather than in-memory timers, so it
+// survives operator restarts and leader changes: the namespace watch enqueues the request again on startup.
+func (r *NamespacelabelReconciler) reconcileSticky(ctx context.Context, namespaceName string) (ctrl.Result, error) {
+	defer r.lockNamespace(namespaceName)()
+	var namespace corev1.Namespace
+	if err := r.apiReader().Get(ctx, client.ObjectKey{Name: namespaceName}, &namespace); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	original := namespace.DeepCopy()
+	records, err := labels.StickyRecords(&namespace)
+	if err != nil {
+		r.Log.Error(err, "Dropping malformed sticky records", "namespace", namespace.Name)
+	}
+	if expireStickyRecords(&namespace, records, r.now(), r.Log) > 0 || err != nil {
+		if err := labels.SetStickyRecords(&namespace, records); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Patch(ctx, &namespace, client.MergeFrom(original), client.FieldOwner(r.fieldManager())); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch namespace: %w", err)
+		}
+	}
+
+	var next time.Time
+	for _, record := range records {
+		if next.IsZero() || record.Expires.Time.Before(next) {
+			next = record.Expires.Time
+		}
+	}
+	if next.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(r.now())}, nil
+}
+
+// expireStickyRecords drops the records whose grace period ended at now and removes their labels from the namespace,
+// like the finalizer would have, except for labels changed since. It returns how many records expired.
+func expireStickyRecords(namespace *corev1.Namespace, records map[string]labels.StickyRecord, now time.Time, logger logr.Logger) int {
+	expired := 0
+	for identity, record := range records {
+		if now.Before(record.Expires.Time) {
+			continue
+		}
+		logger.Info("Sticky grace period ended, removing labels", "namespace", namespace.Name, "namespaceLabel", identity)
+		for key, value := range record.Labels {
+			if namespace.Labels[key] == value {
+				delete(namespace.Labels, key)
+				delete(namespace.Annotations, labels.MirrorAnnotationKey(key))
+			}
+		}
+		delete(records, identity)
+		expired++
+	}
+	return expired
+}
+
+// writeStatus refreshes the derived ActiveConditions field, writes the Namespacelabel status,
+// and then updates the managed labels gauge to match. The write is skipped when the status is unchanged,
+// so that resyncs do not churn the API server. With StatusBatchWindow set, a changed status is queued instead.
+func (r *NamespacelabelReconciler) writeStatus(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	namespaceLabel.Status.ActiveConditions = activeConditions(namespaceLabel.Status.Conditions)
+	if r.statusUnchanged(ctx, namespaceLabel) {
+		r.Log.Info("Status already up to date, skipped write", "namespaceLabel", namespaceLabel.Name)
+		r.dequeueStatus(client.ObjectKeyFromObject(namespaceLabel))
+	} else if r.StatusBatchWindow > 0 {
+		r.queueStatus(namespaceLabel)
+	} else if err := r.Status().Update(ctx, namespaceLabel); err != nil {
+		return fmt.Errorf("failed to update Namespacelabel status: %w", err)
+	}
+	metrics.SetManagedLabels(finalizer.TargetNamespace(namespaceLabel), client.ObjectKeyFromObject(namespaceLabel).String(),
+		len(namespaceLabel.Status.AppliedLabels))
+	return nil
+}
+
+// statusUnchanged reports whether the stored status already equals the computed one. The stored copy is only trusted
+// when it is the same resource version the reconcile worked from; a failed read counts as changed.
+func (r *NamespacelabelReconciler) statusUnchanged(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) bool {
+	var current labelsv1alpha1.Namespacelabel
+	if err := r.Get(ctx, client.ObjectKeyFromObject(namespaceLabel), &current); err != nil {
+		return false
+	}
+	return current.ResourceVersion == namespaceLabel.ResourceVersion && equality.Semantic.DeepEqual(current.Status, namespaceLabel.Status)
+}
+
+// queueStatus queues the Namespacelabel's status for the batch of its namespace, replacing any status queued for it
+// earlier. runStatusBatches flushes the batches.
+func (r *NamespacelabelReconciler) queueStatus(namespaceLabel *labelsv1alpha1.Namespacelabel) {
+	r.statusBatchesMu.Lock()
+	defer r.statusBatchesMu.Unlock()
+	if r.statusBatches == nil {
+		r.statusBatches = make(map[string]map[types.NamespacedName]*labelsv1alpha1.NamespacelabelStatus)
+	}
+	batch, ok := r.statusBatches[namespaceLabel.Namespace]
+	if !ok {
+		batch = make(map[types.NamespacedName]*labelsv1alpha1.NamespacelabelStatus)
+		r.statusBatches[namespaceLabel.Namespace] = batch
+	}
+	batch[client.ObjectKeyFromObject(namespaceLabel)] = namespaceLabel.Status.DeepCopy()
+}
+
+// dequeueStatus drops the status queued for the Namespacelabel, once a later reconcile found the stored one current.
+func (r *NamespacelabelReconciler) dequeueStatus(key types.NamespacedName) {
+	r.statusBatchesMu.Lock()
+	defer r.statusBatchesMu.Unlock()
+	delete(r.statusBatches[key.Namespace], key)
+}
+
+// statusQueued reports whether a status of the Namespacelabel is queued and not yet written.
+func (r *NamespacelabelReconciler) statusQueued(key types.NamespacedName) bool {
+	r.statusBatchesMu.Lock()
+	defer r.statusBatchesMu.Unlock()
+	_, ok := r.statusBatches[key.Namespace][key]
+	return ok
+}
+
+// statusDrainTimeout bounds how long runStatusBatches spends writing the remaining statuses when the manager stops.
+const statusDrainTimeout = 10 * time.Second
+
+// runStatusBatches flushes every queued status batch once per StatusBatchWindow until ctx is done, then drains the
+// batches left. It runs as a runnable of the manager, so batches are only written while this replica leads, and none
+// is dropped on a clean shutdown.
+func (r *NamespacelabelReconciler) runStatusBatches(ctx context.Context) error {
+	ticker := time.NewTicker(r.StatusBatchWindow)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.flushStatusBatches(ctx)
+		case <-ctx.Done():
+			drainCtx, cancel := context.WithTimeout(context.Background(), statusDrainTimeout)
+			defer cancel()
+			r.flushStatusBatches(drainCtx)
+			return nil
+		}
+	}
+}
+
+// flushStatusBatches flushes the batch of every namespace with queued statuses.
+func (r *NamespacelabelReconciler) flushStatusBatches(ctx context.Context) {
+	r.statusBatchesMu.Lock()
+	namespaces := make([]string, 0, len(r.statusBatches))
+	for namespace := range r.statusBatches {
+		namespaces = append(namespaces, namespace)
+	}
+	r.statusBatchesMu.Unlock()
+	for _, namespace := range namespaces {
+		r.flushStatusBatch(ctx, namespace)
+	}
+}
+
+// flushStatusBatch writes the statuses queued for the namespace. Each is written onto a fresh read of its
+// Namespacelabel, since the reconcile that queued it may have updated the object since, and skipped when the stored
+// status already matches. The reconcile outcome is kept from the fresh read, see withReconcileOutcome.
+func (r *NamespacelabelReconciler) flushStatusBatch(ctx context.Context, namespace string) {
+	r.statusBatchesMu.Lock()
+	batch := r.statusBatches[namespace]
+	delete(r.statusBatches, namespace)
+	r.statusBatchesMu.Unlock()
+
+	for key, status := range batch {
+		var current labelsv1alpha1.Namespacelabel
+		if err := r.apiReader().Get(ctx, key, &current); err != nil {
+			if !apierrors.IsNotFound(err) {
+				r.Log.Error(err, "Failed to read Namespacelabel for a batched status write", "namespaceLabel", key.String())
+			}
+			continue
+		}
+		merged := withReconcileOutcome(status, &current.Status)
+		if equality.Semantic.DeepEqual(current.Status, *merged) {
+			continue
+		}
+		current.Status = *merged
+		if err := r.Status().Update(ctx, &current); err != nil {
+			r.Log.Error(err, "Failed to write batched Namespacelabel status", "namespaceLabel", key.String())
+		}
+	}
+	if len(batch) > 0 {
+		r.Log.Info("Flushed batched status writes", "namespace", namespace, "namespaceLabels", len(batch))
+	}
+}
+
+// withReconcileOutcome returns a copy of the queued status carrying the stored reconcile outcome: the phase, the failure
+// count and the ReconcileError condition. reportReconcileError patches those directly, after the status was queued, so
+// the queued copy of them is stale.
+func withReconcileOutcome(queued, stored *labelsv1alpha1.NamespacelabelStatus) *labelsv1alpha1.NamespacelabelStatus {
+	merged := queued.DeepCopy()
+	merged.Phase = stored.Phase
+	merged.ConsecutiveFailures = stored.ConsecutiveFailures
+	merged.FailedGeneration = stored.FailedGeneration
+	meta.RemoveStatusCondition(&merged.Conditions, string(labelsv1alpha1.ConditionReconcileError))
+	if condition := meta.FindStatusCondition(stored.Conditions, string(labelsv1alpha1.ConditionReconcileError)); condition != nil {
+		meta.SetStatusCondition(&merged.Conditions, *condition)
+	}
+	merged.ActiveConditions = activeConditions(merged.Conditions)
+	return merged
+}
+
+// activeConditions returns the sorted types of the conditions whose status is True.
+func activeConditions(conditions []metav1.Condition) []string {
+	var active []string
+	for _, condition := range conditions {
+		if condition.Status == metav1.ConditionTrue {
+			active = append(active, condition.Type)
+		}
+	}
+	sort.Strings(active)
+	return active
+}
+
+func (r *NamespacelabelReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.StatusBatchWindow > 0 {
+		if err := mgr.Add(manager.RunnableFunc(r.runStatusBatches)); err != nil {
+			return err
+		}
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&labelsv1alpha1.Namespacelabel{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Watches(&corev1.Namespace{}, r.namespaceEventHandler()).
+		// ConfigMaps are watched by metadata only: the mapping needs just their keys, and caching every ConfigMap
+		// in the cluster in full would cost far more memory than the few the operator reads.
+		WatchesMetadata(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsFromConfigMap),
+		).
+		Complete(r)
+}
+
+// enqueueRequestsFromConfigMap reconciles the Namespacelabels reading their labels from the ConfigMap when it changes.
+// A change to the deny ConfigMap or the protected labels ConfigMap reconciles every Namespacelabel, since any of them
+// may target a namespace the former lists or set a key the latter protects, and a change to the template variables ConfigMap reconciles every Namespacelabel with templating enabled.
+func (r *NamespacelabelReconciler) enqueueRequestsFromConfigMap(ctx context.Context, configMap client.Object) []reconcile.Request {
+	isProtected := r.isProtectedConfigMap(configMap)
+	if isProtected {
+		r.reloadProtected(ctx)
+	}
+	isDenyList := denylist.IsConfigMap(configMap) || isProtected
+	isVars := vars.IsConfigMap(configMap)
+	var listOptions []client.ListOption
+	if !isDenyList && !isVars {
+		listOptions = append(listOptions, client.InNamespace(configMap.GetNamespace()))
+	}
+
+	namespaceLabelList := &labelsv1alpha1.NamespacelabelList{}
+	if err := r.List(ctx, namespaceLabelList, listOptions...); err != nil {
+		r.Log.Error(err, "Failed to list Namespacelabel resources", "Namespace", configMap.GetNamespace())
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, item := range namespaceLabelList.Items {
+		readsLabels := item.Namespace == configMap.GetNamespace() && item.Spec.LabelsFrom != nil && item.Spec.LabelsFrom.Name == configMap.GetName()
+		if !isDenyList && !readsLabels && !(isVars && item.Spec.EnableTemplating) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+	}
+	return requests
+}
+
+// namespaceEventHandler maps namespace events to the Namespacelabels targeting the namespace. It is the plain
+// EnqueueRequestsFromMapFunc handler, which maps create events like any other, so a namespace created after a
+// Namespacelabel whose selector matches it is labelled without extra handling; it is named so tests can deliver
+// events to the handler the controller watches with.
+func (r *NamespacelabelReconciler) namespaceEventHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsFromNamespace)
+}
+
+// enqueueRequestsFromNamespace triggers reconciliation for related Namespacelabel resources when a Namespace is
+// created or changes. Namespacelabels in other namespaces are related when they target the namespace by name or
+// selector, or last applied to it.
+func (r *NamespacelabelReconciler) enqueueRequestsFromNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	ns, ok := namespace.(*corev1.Namespace)
+	if !ok {
+		r.Log.Error(nil, "Failed to cast object to Namespace", "object", namespace)
+		return []reconcile.Request{}
+	}
+
+	namespaceLabelList := &labelsv1alpha1.NamespacelabelList{}
+	if err := r.List(ctx, namespaceLabelList); err != nil {
+		r.Log.Error(err, "Failed to list Namespacelabel resources", "Namespace", ns.Name)
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	if _, ok := ns.Annotations[labels.StickyAnnotation]; ok {
+		requests = append(requests, stickyRequest(ns.Name))
+	}
+	for _, item := range namespaceLabelList.Items {
+		if !targetsNamespace(&item, ns) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
 				Name:      item.Name,
 				Namespace: item.Namespace,
 			},
@@ -216,3 +2597,19 @@ func (r *NamespacelabelReconciler) enqueueRequestsFromNamespace(ctx context.Cont
 	r.Log.Info("Enqueued reconciliation requests", "Namespace", ns.Name, "RequestCount", len(requests))
 	return requests
 }
+
+// targetsNamespace reports whether the Namespacelabel targets the namespace, or last applied labels to it.
+func targetsNamespace(namespaceLabel *labelsv1alpha1.Namespacelabel, namespace *corev1.Namespace) bool {
+	if namespaceLabel.Status.TargetNamespace == namespace.Name {
+		return true
+	}
+	switch {
+	case namespaceLabel.Spec.NamespaceName != "":
+		return namespaceLabel.Spec.NamespaceName == namespace.Name
+	case namespaceLabel.Spec.NamespaceLabelSelector != nil:
+		selector, err := metav1.LabelSelectorAsSelector(namespaceLabel.Spec.NamespaceLabelSelector)
+		return err == nil && selector.Matches(k8slabels.Set(namespace.Labels))
+	default:
+		return namespaceLabel.Namespace == namespace.Name
+	}
+}
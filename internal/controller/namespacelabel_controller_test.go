@@ -2,18 +2,52 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/go-logr/logr"
+
 	labelsv1alpha1 "github.com/matanamar10/namespacelabel-operator/api/v1alpha1"
+	"github.com/matanamar10/namespacelabel-operator/internal/conditions"
+	"github.com/matanamar10/namespacelabel-operator/internal/denylist"
+	"github.com/matanamar10/namespacelabel-operator/internal/events"
+	"github.com/matanamar10/namespacelabel-operator/internal/labels"
+	"github.com/matanamar10/namespacelabel-operator/internal/metrics"
+	"github.com/matanamar10/namespacelabel-operator/internal/notify"
+	"github.com/matanamar10/namespacelabel-operator/internal/throttle"
+	"github.com/matanamar10/namespacelabel-operator/internal/vars"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
 )
 
 var _ = Describe("Namespacelabel Controller", func() {
@@ -29,27 +63,30 @@ var _ = Describe("Namespacelabel Controller", func() {
 		recorder *record.FakeRecorder
 	)
 
-	deleteNamespace := func(name string) {
-		namespace := &corev1.Namespace{}
-		Expect(k8sClient.Delete(ctx, namespace)).To(Succeed())
-		Eventually(func() bool {
-			err := k8sClient.Get(ctx, types.NamespacedName{Name: name}, namespace)
-			return errors.IsNotFound(err)
-		}, timeout, interval).Should(BeTrue(), "Namespace was not fully deleted")
+	// updateNamespace changes a namespace through the envtest API server, retrying on conflicts with the manager.
+	updateNamespace := func(name string, mutate func(namespace *corev1.Namespace)) {
+		Eventually(func() error {
+			namespace := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name}, namespace)).To(Succeed())
+			mutate(namespace)
+			return k8sClient.Update(ctx, namespace)
+		}, timeout, interval).Should(Succeed())
 	}
 
-	createNamespace := func(name string) {
-		namespace := &corev1.Namespace{
-			ObjectMeta: metav1.ObjectMeta{Name: name},
-		}
+	// resetNamespace creates the namespace, or clears the labels and annotations an earlier test left on it. envtest
+	// runs no namespace controller, so a deleted namespace would stay Terminating instead of going away.
+	resetNamespace := func(name string) {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
 		err := k8sClient.Create(ctx, namespace)
-		if errors.IsAlreadyExists(err) {
-			By("Namespace already exists, ensuring it's clean")
-			deleteNamespace(name)
-			Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
-		} else {
-			Expect(err).To(Succeed())
+		if !errors.IsAlreadyExists(err) {
+			Expect(err).NotTo(HaveOccurred())
+			return
 		}
+		By("Namespace already exists, ensuring it's clean")
+		updateNamespace(name, func(namespace *corev1.Namespace) {
+			namespace.Labels = nil
+			namespace.Annotations = nil
+		})
 	}
 
 	deleteAllNamespaceLabels := func() {
@@ -73,17 +110,59 @@ var _ = Describe("Namespacelabel Controller", func() {
 		}
 	}
 
+	// newNamespace returns the namespace most tests reconcile against, carrying the given labels.
+	newNamespace := func(namespaceLabels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: NamespaceName, Labels: namespaceLabels}}
+	}
+
+	// newNamespacelabel returns the Namespacelabel most tests reconcile, with the given spec.
+	newNamespacelabel := func(spec labelsv1alpha1.NamespacelabelSpec) *labelsv1alpha1.Namespacelabel {
+		return &labelsv1alpha1.Namespacelabel{
+			ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+			Spec:       spec,
+		}
+	}
+
+	// requestFor returns the reconcile request for a Namespacelabel.
+	requestFor := func(namespaceLabel client.Object) ctrl.Request {
+		return ctrl.Request{NamespacedName: client.ObjectKeyFromObject(namespaceLabel)}
+	}
+
+	// mustReconcile runs a single reconcile and expects it to succeed.
+	mustReconcile := func(reconciler *NamespacelabelReconciler, request ctrl.Request) ctrl.Result {
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	// liveLabels polls the labels of an object through the envtest API server.
+	liveLabels := func(obj client.Object) func() map[string]string {
+		return func() map[string]string {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+			return obj.GetLabels()
+		}
+	}
+
+	// liveStatus polls the status of a Namespacelabel through the envtest API server.
+	liveStatus := func(namespaceLabel *labelsv1alpha1.Namespacelabel) func() labelsv1alpha1.NamespacelabelStatus {
+		return func() labelsv1alpha1.NamespacelabelStatus {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(namespaceLabel), namespaceLabel)).To(Succeed())
+			return namespaceLabel.Status
+		}
+	}
+
 	BeforeEach(func() {
 		ctx = context.Background()
-		recorder = record.NewFakeRecorder(100)
+		By("Using the recorder of the manager's reconciler, emptied of earlier tests' events")
+		recorder = eventRecorder
+		drainEvents(recorder)
 		By("Creating a fresh namespace for the test")
-		createNamespace(NamespaceName)
+		resetNamespace(NamespaceName)
 	})
 
 	AfterEach(func() {
 		By("Cleaning up test resources")
 		deleteAllNamespaceLabels()
-		deleteNamespace(NamespaceName)
 	})
 
 	Context("Full CRUD operations with events", func() {
@@ -212,4 +291,4103 @@ var _ = Describe("Namespacelabel Controller", func() {
 			Eventually(getNextEvent, timeout, interval).Should(ContainSubstring("DuplicateLabelSkipped"))
 		})
 	})
-})
+
+	Context("Reconciling through the manager", func() {
+		var received []string
+
+		// receivedEvents accumulates the events of the manager's reconciler, for Eventually to poll.
+		receivedEvents := func() []string {
+			received = append(received, drainEvents(recorder)...)
+			return received
+		}
+
+		BeforeEach(func() {
+			received = nil
+		})
+
+		It("should apply managed labels to ConfigMaps and remove them on cleanup", func() {
+			configMaps := []*corev1.ConfigMap{
+				{ObjectMeta: metav1.ObjectMeta{Name: "cm-1", Namespace: NamespaceName}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "cm-2", Namespace: NamespaceName, Labels: map[string]string{"app": "demo"}}},
+			}
+			for _, cm := range configMaps {
+				Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(k8sClient.Delete(ctx, cm)).To(Succeed())
+				})
+			}
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:      map[string]string{"team": "platform", "protected-label": "value"},
+				PropagateTo: []string{"v1/configmaps"},
+			})
+			Expect(k8sClient.Create(ctx, labelsCR)).To(Succeed())
+
+			By("Verifying the managed labels were propagated to every ConfigMap")
+			for _, cm := range configMaps {
+				Eventually(liveLabels(cm), timeout, interval).Should(SatisfyAll(
+					HaveKeyWithValue("team", "platform"),
+					Not(HaveKey("protected-label")),
+				))
+			}
+
+			By("Deleting the Namespacelabel CR")
+			Expect(k8sClient.Delete(ctx, labelsCR)).To(Succeed())
+
+			By("Verifying the propagated labels were removed while unrelated labels were kept")
+			Eventually(liveLabels(configMaps[1]), timeout, interval).Should(SatisfyAll(
+				Not(HaveKey("team")),
+				HaveKeyWithValue("app", "demo"),
+			))
+		})
+
+		It("should skip unsupported propagation targets such as namespaces", func() {
+			resetNamespace("other-namespace")
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:      map[string]string{"team": "platform"},
+				PropagateTo: []string{"v1/namespaces"},
+			})
+			Expect(k8sClient.Create(ctx, labelsCR)).To(Succeed())
+
+			By("Verifying only the target namespace was labelled")
+			Eventually(liveLabels(newNamespace(nil)), timeout, interval).Should(HaveKeyWithValue("team", "platform"))
+			other := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other-namespace"}}
+			Consistently(liveLabels(other), 3*interval, interval).ShouldNot(HaveKey("team"))
+		})
+
+		DescribeTable("should emit DriftDetected and restore managed labels changed out-of-band",
+			func(mutate func(namespace *corev1.Namespace), drift []string) {
+				labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"team": "platform", "tier": "gold"},
+				})
+				Expect(k8sClient.Create(ctx, labelsCR)).To(Succeed())
+				Eventually(func() map[string]string {
+					return liveStatus(labelsCR)().AppliedLabels
+				}, timeout, interval).Should(Equal(map[string]string{"team": "platform", "tier": "gold"}))
+
+				By("Changing the namespace labels out-of-band")
+				updateNamespace(NamespaceName, mutate)
+
+				By("Verifying drift events were emitted and the labels restored")
+				var expected []interface{}
+				for _, message := range drift {
+					expected = append(expected, And(ContainSubstring("DriftDetected"), ContainSubstring(message)))
+				}
+				Eventually(receivedEvents, timeout, interval).Should(ContainElements(expected...))
+				Eventually(liveLabels(newNamespace(nil)), timeout, interval).Should(SatisfyAll(
+					HaveKeyWithValue("team", "platform"),
+					HaveKeyWithValue("tier", "gold"),
+				))
+			},
+			Entry("one label removed and another changed", func(namespace *corev1.Namespace) {
+				delete(namespace.Labels, "team")
+				namespace.Labels["tier"] = "silver"
+			}, []string{"team=platform was removed", "from gold to silver"}),
+			Entry("the labels reset to nil", func(namespace *corev1.Namespace) {
+				namespace.Labels = nil
+			}, []string{"team=platform was removed", "tier=gold was removed"}),
+		)
+
+		It("should attribute the namespace labels to the operator's field manager", func() {
+			Expect(k8sClient.Create(ctx, newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform"},
+			}))).To(Succeed())
+
+			Eventually(func() []string {
+				namespace := &corev1.Namespace{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+				var managers []string
+				for _, entry := range namespace.ManagedFields {
+					if entry.FieldsV1 != nil && strings.Contains(string(entry.FieldsV1.Raw), `"f:team"`) {
+						managers = append(managers, entry.Manager)
+					}
+				}
+				return managers
+			}, timeout, interval).Should(ContainElement(DefaultFieldManager))
+		})
+
+		It("should remove only the labels it applied on deletion", func() {
+			updateNamespace(NamespaceName, func(namespace *corev1.Namespace) {
+				namespace.Labels["env"] = "prod"
+			})
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"env": "prod", "team": "platform", "protected-label": "value"},
+			})
+			Expect(k8sClient.Create(ctx, labelsCR)).To(Succeed())
+
+			By("Waiting for the apply, which skips the pre-existing env label as a duplicate")
+			Eventually(func() map[string]string {
+				return liveStatus(labelsCR)().AppliedLabels
+			}, timeout, interval).Should(Equal(map[string]string{"team": "platform"}))
+
+			By("Labelling the namespace with the protected key out-of-band")
+			updateNamespace(NamespaceName, func(namespace *corev1.Namespace) {
+				namespace.Labels["protected-label"] = "value"
+			})
+
+			By("Deleting the Namespacelabel and waiting for its finalizer to be removed")
+			Expect(k8sClient.Delete(ctx, labelsCR)).To(Succeed())
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR))
+			}, timeout, interval).Should(BeTrue())
+
+			By("Verifying only the applied label was removed")
+			Expect(liveLabels(newNamespace(nil))()).To(SatisfyAll(
+				Not(HaveKey("team")),
+				HaveKeyWithValue("env", "prod"),
+				HaveKeyWithValue("protected-label", "value"),
+			))
+		})
+
+		It("should advance observedGeneration and the conditions' generation with the spec", func() {
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform"},
+			})
+			Expect(k8sClient.Create(ctx, labelsCR)).To(Succeed())
+			Eventually(func() int64 {
+				return liveStatus(labelsCR)().ObservedGeneration
+			}, timeout, interval).Should(BeEquivalentTo(1))
+
+			By("Changing the spec")
+			Eventually(func() error {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+				labelsCR.Spec.Labels["env"] = "prod"
+				return k8sClient.Update(ctx, labelsCR)
+			}, timeout, interval).Should(Succeed())
+
+			By("Verifying the status caught up with the new generation")
+			Eventually(func() int64 {
+				return liveStatus(labelsCR)().ObservedGeneration
+			}, timeout, interval).Should(BeEquivalentTo(2))
+			for _, conditionType := range []labelsv1alpha1.ConditionType{labelsv1alpha1.ConditionConfigLoaded, labelsv1alpha1.ConditionLabelsSkipped} {
+				condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(conditionType))
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.ObservedGeneration).To(BeEquivalentTo(2), "condition %s", conditionType)
+			}
+		})
+
+		It("should label a namespace matching the selector that is created after the Namespacelabel", func() {
+			// The namespace is left behind: envtest cannot delete it, and no other test selects on this label.
+			selector := map[string]string{"selector-test": "created-later"}
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:                 map[string]string{"team": "platform"},
+				NamespaceLabelSelector: &metav1.LabelSelector{MatchLabels: selector},
+			})
+			Expect(k8sClient.Create(ctx, labelsCR)).To(Succeed())
+
+			By("Waiting for the reconcile that finds no matching namespace")
+			Eventually(func() bool {
+				return meta.IsStatusConditionFalse(liveStatus(labelsCR)().Conditions, string(labelsv1alpha1.ConditionTargetResolved))
+			}, timeout, interval).Should(BeTrue())
+
+			By("Creating a matching namespace")
+			target := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "created-later", Labels: selector}}
+			Expect(k8sClient.Create(ctx, target)).To(Succeed())
+
+			By("Verifying the new namespace was labelled and reported as the target")
+			Eventually(liveLabels(target), timeout, interval).Should(HaveKeyWithValue("team", "platform"))
+			Eventually(func() string {
+				return liveStatus(labelsCR)().TargetNamespace
+			}, timeout, interval).Should(Equal("created-later"))
+		})
+	})
+
+	Context("Skipping labels", func() {
+		DescribeTable("should apply the remaining labels and record why each skipped label was skipped",
+			func(c skipCase) {
+				for key, value := range c.env {
+					DeferCleanup(os.Setenv, key, os.Getenv(key))
+					Expect(os.Setenv(key, value)).To(Succeed())
+				}
+				namespace := newNamespace(c.namespaceLabels)
+				namespace.Annotations = c.namespaceAnnotations
+				labelsCR := newNamespacelabel(c.spec)
+				labelsCR.Annotations = c.annotations
+				reconciler, recorder := newTestReconciler(namespace, labelsCR)
+
+				mustReconcile(reconciler, requestFor(labelsCR))
+
+				By("Verifying the remaining labels were applied and the skipped ones were not")
+				Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)).To(Succeed())
+				for key, value := range c.applied {
+					Expect(namespace.Labels).To(HaveKeyWithValue(key, value))
+				}
+				for key := range c.skipReasons {
+					Expect(namespace.Labels).NotTo(HaveKeyWithValue(key, c.spec.Labels[key]))
+				}
+
+				By("Verifying the skip reasons were recorded and reported")
+				Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+				Expect(labelsCR.Status.SkipReasons).To(Equal(c.skipReasons))
+				Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring(c.event)))
+			},
+			Entry("keys excluded by the namespace owner", skipCase{
+				namespaceAnnotations: map[string]string{labels.ExcludeAnnotation: "cost-center, owner"},
+				spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"cost-center": "1234", "team": "platform"},
+				},
+				applied:     map[string]string{"team": "platform"},
+				skipReasons: map[string]labelsv1alpha1.SkipReason{"cost-center": labelsv1alpha1.SkipReasonNamespaceExcluded},
+				event:       "NamespaceExcludedLabelSkipped Label cost-center=1234",
+			}),
+			Entry("labels past the size budget in application order", skipCase{
+				// "app"+"web" and "env"+"prod" fit in 14 bytes; "team"+"platform" does not.
+				env: map[string]string{MaxTotalLabelBytesEnv: "14"},
+				spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"app": "web", "env": "prod", "team": "platform"},
+				},
+				applied:     map[string]string{"app": "web", "env": "prod"},
+				skipReasons: map[string]labelsv1alpha1.SkipReason{"team": labelsv1alpha1.SkipReasonSizeBudgetExceeded},
+				event:       "SizeBudgetExceeded Label team=platform",
+			}),
+			Entry("a key maintained by Kubernetes, even when protected labels are exempt", skipCase{
+				namespaceLabels: map[string]string{corev1.LabelMetadataName: NamespaceName},
+				annotations:     map[string]string{labels.ExemptProtectedAnnotation: "true"},
+				spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{corev1.LabelMetadataName: "renamed", "team": "platform"},
+				},
+				applied:     map[string]string{corev1.LabelMetadataName: NamespaceName, "team": "platform"},
+				skipReasons: map[string]labelsv1alpha1.SkipReason{corev1.LabelMetadataName: labelsv1alpha1.SkipReasonShadowsSystemLabel},
+				event:       "Warning ShadowsSystemLabel Label kubernetes.io/metadata.name is maintained by Kubernetes and was not applied",
+			}),
+			Entry("a value whose transform makes it too long", skipCase{
+				spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels:     map[string]string{"team": "platform", "description": strings.Repeat("a", 60) + " team"},
+					Transforms: map[string]labelsv1alpha1.LabelTransform{"description": labelsv1alpha1.TransformUpper},
+				},
+				applied:     map[string]string{"team": "platform"},
+				skipReasons: map[string]labelsv1alpha1.SkipReason{"description": labelsv1alpha1.SkipReasonValueTooLongAfterTransform},
+				event:       "ValueTooLongAfterTransform Label description",
+			}),
+			Entry("a value that does not match its pattern", skipCase{
+				spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"cost-center": "cc-1234", "region": "eu-west-1-extra", "team": "platform"},
+					ValuePatterns: map[string]string{
+						"cost-center": "cc-[0-9]{4}",
+						"region":      "[a-z]{2}-[a-z]+-[0-9]",
+					},
+				},
+				applied:     map[string]string{"cost-center": "cc-1234", "team": "platform"},
+				skipReasons: map[string]labelsv1alpha1.SkipReason{"region": labelsv1alpha1.SkipReasonValuePatternMismatch},
+				event:       "ValuePatternMismatch Label region",
+			}),
+			Entry("a value whose pattern is not a valid regular expression", skipCase{
+				spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels:        map[string]string{"cost-center": "cc-1234"},
+					ValuePatterns: map[string]string{"cost-center": "cc-[0-9"},
+				},
+				skipReasons: map[string]labelsv1alpha1.SkipReason{"cost-center": labelsv1alpha1.SkipReasonValuePatternMismatch},
+				event:       "invalid value pattern",
+			}),
+			Entry("a value outside its enum", skipCase{
+				spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"env": "prod", "tier": "gold", "team": "platform"},
+					ValueEnums: map[string][]string{
+						"env":  {"dev", "prod"},
+						"tier": {"bronze", "silver"},
+					},
+				},
+				applied:     map[string]string{"env": "prod", "team": "platform"},
+				skipReasons: map[string]labelsv1alpha1.SkipReason{"tier": labelsv1alpha1.SkipReasonValueNotAllowed},
+				event:       `ValueNotAllowed Label tier was not applied: value "gold" is not one of bronze, silver`,
+			}),
+		)
+	})
+
+	Context("Recording the apply timestamp", func() {
+		It("should annotate the namespace on each apply that changes it and remove the annotation on cleanup", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:               map[string]string{"team": "platform"},
+				RecordApplyTimestamp: true,
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			fakeClock := clocktesting.NewFakePassiveClock(time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC))
+			reconciler.Clock = fakeClock
+			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: NamespaceLabelCR, Namespace: NamespaceName}}
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the namespace carries an RFC3339 last-applied annotation")
+			stamped := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, stamped)).To(Succeed())
+			Expect(stamped.Annotations).To(HaveKeyWithValue(labels.LastAppliedAnnotation, "2024-06-03T10:00:00Z"))
+
+			By("Reconciling again without changes and verifying the annotation is kept")
+			fakeClock.SetTime(fakeClock.Now().Add(time.Minute))
+			mustReconcile(reconciler, request)
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, stamped)).To(Succeed())
+			Expect(stamped.Annotations).To(HaveKeyWithValue(labels.LastAppliedAnnotation, "2024-06-03T10:00:00Z"))
+
+			By("Changing a label and verifying the annotation is refreshed")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			labelsCR.Spec.Labels["team"] = "payments"
+			Expect(reconciler.Update(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, stamped)).To(Succeed())
+			Expect(stamped.Annotations).To(HaveKeyWithValue(labels.LastAppliedAnnotation, "2024-06-03T10:01:00Z"))
+
+			By("Deleting the Namespacelabel CR and reconciling the cleanup")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(reconciler.Delete(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+
+			By("Verifying the annotation was removed")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, stamped)).To(Succeed())
+			Expect(stamped.Annotations).NotTo(HaveKey(labels.LastAppliedAnnotation))
+		})
+
+		It("should leave the annotation alone for a Namespacelabel that did not write it", func() {
+			namespace := newNamespace(nil)
+			stamping := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:               map[string]string{"team": "platform"},
+				RecordApplyTimestamp: true,
+			})
+			other := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"env": "prod"}},
+			}
+			reconciler, _ := newTestReconciler(namespace, stamping, other)
+			reconciler.Clock = clocktesting.NewFakePassiveClock(time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC))
+
+			By("Reconciling both Namespacelabels, each twice")
+			for i := 0; i < 2; i++ {
+				for _, labelsCR := range []*labelsv1alpha1.Namespacelabel{stamping, other} {
+					mustReconcile(reconciler, requestFor(labelsCR))
+				}
+			}
+
+			By("Verifying the annotation written by the first one is kept")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)).To(Succeed())
+			Expect(namespace.Annotations).To(HaveKeyWithValue(labels.LastAppliedAnnotation, "2024-06-03T10:00:00Z"))
+
+			By("Deleting the Namespacelabel that did not write it and verifying the annotation is kept")
+			Expect(reconciler.Delete(ctx, other)).To(Succeed())
+			mustReconcile(reconciler, requestFor(other))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)).To(Succeed())
+			Expect(namespace.Annotations).To(HaveKeyWithValue(labels.LastAppliedAnnotation, "2024-06-03T10:00:00Z"))
+			Expect(namespace.Labels).NotTo(HaveKey("env"))
+		})
+	})
+
+	Context("Protected labels scoped by namespace selector", func() {
+		It("should protect a key only in namespaces matching the rule selector", func() {
+			By("Configuring a protected rule for billing-* limited to tier=prod namespaces")
+			DeferCleanup(os.Setenv, protectedEnv, os.Getenv(protectedEnv))
+			Expect(os.Setenv(protectedEnv, `[{"key":"billing-*","namespaceSelector":{"matchLabels":{"tier":"prod"}}}]`)).To(Succeed())
+
+			prodNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"tier": "prod"}}}
+			devNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"tier": "dev"}}}
+			prodCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: "prod"},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"billing-code": "1234"}},
+			}
+			devCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: "dev"},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"billing-code": "1234"}},
+			}
+			reconciler, recorder := newTestReconciler(prodNamespace, devNamespace, prodCR, devCR)
+
+			By("Reconciling both Namespacelabel CRs")
+			_, err := reconciler.Reconcile(ctx, requestFor(prodCR))
+			Expect(err).NotTo(HaveOccurred())
+			mustReconcile(reconciler, requestFor(devCR))
+
+			By("Verifying the key was skipped in the prod namespace")
+			namespace := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "prod"}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("billing-code"))
+			Expect(recorder.Events).To(Receive(ContainSubstring("ProtectedLabelSkipped")))
+
+			By("Verifying the key was applied in the dev namespace")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "dev"}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("billing-code", "1234"))
+		})
+	})
+
+	Context("Reflecting partially applied labels in status", func() {
+		It("should record only the labels that landed when another admission controller strips one", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform", "stripped": "value"},
+			})
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if ns, ok := obj.(*corev1.Namespace); ok {
+						delete(ns.Labels, "stripped")
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}, namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying status reflects only the surviving label")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(Equal(map[string]string{"team": "platform"}))
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionPartiallyApplied))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("stripped"))
+		})
+
+		It("should write status and report a partial apply when a step after the namespace write fails", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:      map[string]string{"team": "platform"},
+				PropagateTo: []string{"v1/configmaps"},
+			})
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					if _, ok := list.(*unstructured.UnstructuredList); ok {
+						return errors.NewServiceUnavailable("propagation targets unavailable")
+					}
+					return c.List(ctx, list, opts...)
+				},
+			}, namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).To(HaveOccurred())
+
+			By("Verifying the namespace write happened and status reflects the partial state")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(HaveKeyWithValue("team", "platform"))
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionPartiallyApplied))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(BeEquivalentTo(labelsv1alpha1.ReasonApplyFailed))
+		})
+	})
+
+	Context("Waiting for the webhook server", func() {
+		It("should requeue without touching anything until the webhook is ready", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			webhookReady := false
+			reconciler.WebhookReady = func(_ *http.Request) error {
+				if !webhookReady {
+					return errors.NewServiceUnavailable("webhook server has not been started yet")
+				}
+				return nil
+			}
+			reconciler.WebhookRequeueAfter = time.Second
+			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: NamespaceLabelCR, Namespace: NamespaceName}}
+
+			By("Reconciling while the webhook is not ready")
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Second))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("team"))
+
+			By("Reconciling once the webhook is ready")
+			webhookReady = true
+			result, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+		})
+	})
+
+	Context("Ordering label application", func() {
+		It("should apply keys listed in labelOrder first and the rest lexically", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:        map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"},
+				LabelOrder:    []string{"c", "a", "missing"},
+				VerboseEvents: true,
+			})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the applied event lists the keys in order")
+			Expect(recorder.Events).To(Receive(Equal("Normal AppliedLabels Applied labels in order: c=3, a=1, b=2, d=4")))
+		})
+	})
+
+	Context("Handling a recreated namespace", func() {
+		It("should not carry previously applied labels over to a namespace recreated with the same name", func() {
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: NamespaceName, UID: "uid-1"}}
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: NamespaceLabelCR, Namespace: NamespaceName}}
+
+			By("Reconciling against the original namespace")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.NamespaceUID).To(BeEquivalentTo("uid-1"))
+			Expect(labelsCR.Status.AppliedLabels).To(HaveKeyWithValue("team", "platform"))
+
+			By("Deleting the namespace and recreating it with a label set by someone else")
+			Expect(reconciler.Delete(ctx, namespace)).To(Succeed())
+			recreated := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   NamespaceName,
+				UID:    "uid-2",
+				Labels: map[string]string{"team": "platform"},
+			}}
+			Expect(reconciler.Create(ctx, recreated)).To(Succeed())
+
+			By("Reconciling against the recreated namespace")
+			mustReconcile(reconciler, request)
+
+			By("Verifying the pre-existing label is treated as foreign rather than owned")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.NamespaceUID).To(BeEquivalentTo("uid-2"))
+			Expect(labelsCR.Status.AppliedLabels).NotTo(HaveKey("team"))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("NamespaceRecreated")))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("DuplicateLabelSkipped")))
+		})
+	})
+
+	Context("Strict handling of protected labels", func() {
+		It("should apply nothing and report a violation when a desired label is protected", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:          map[string]string{"team": "platform", "protected-label": "value"},
+				StrictProtected: true,
+			})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying no label was applied")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("team"))
+
+			By("Verifying the violation condition and warning event")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionStrictProtectedViolation))).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionLabelsApplied))).To(BeTrue())
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("StrictProtectedViolation")))
+		})
+
+		It("should apply every label when no desired label is protected", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:          map[string]string{"team": "platform"},
+				StrictProtected: true,
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the label was applied and no violation is reported")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionStrictProtectedViolation))).To(BeTrue())
+		})
+	})
+
+	Context("Exported condition constants", func() {
+		It("should report conditions using the exported types and reasons", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform", "protected-label": "value"},
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying each condition matches the exported constants")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			expected := map[labelsv1alpha1.ConditionType]labelsv1alpha1.ConditionReason{
+				labelsv1alpha1.ConditionLabelsApplied:    labelsv1alpha1.ReasonLabelsReconciled,
+				labelsv1alpha1.ConditionLabelsSkipped:    labelsv1alpha1.ReasonProtectedLabelsHandled,
+				labelsv1alpha1.ConditionDuplicateLabels:  labelsv1alpha1.ReasonDuplicateLabelsHandled,
+				labelsv1alpha1.ConditionPartiallyApplied: labelsv1alpha1.ReasonAllLabelsPresent,
+			}
+			for conditionType, reason := range expected {
+				condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(conditionType))
+				Expect(condition).NotTo(BeNil(), "missing condition %s", conditionType)
+				Expect(condition.Reason).To(BeEquivalentTo(reason))
+			}
+			Expect(meta.IsStatusConditionTrue(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionLabelsSkipped))).To(BeTrue())
+		})
+	})
+
+	Context("Attributing namespace writes to a field manager", func() {
+		It("should use the configured field manager", func() {
+			reconciler := &NamespacelabelReconciler{FieldManager: "custom-manager"}
+			Expect(reconciler.fieldManager()).To(Equal("custom-manager"))
+		})
+
+		It("should default the field manager when none is configured", func() {
+			reconciler, _ := newTestReconciler()
+			Expect(reconciler.fieldManager()).To(Equal(DefaultFieldManager))
+		})
+	})
+
+	Context("Guarding against an empty spec", func() {
+		BeforeEach(func() {
+			DeferCleanup(os.Setenv, GuardEmptySpecEnv, os.Getenv(GuardEmptySpecEnv))
+			Expect(os.Setenv(GuardEmptySpecEnv, "true")).To(Succeed())
+		})
+
+		It("should hold back an unconfirmed empty spec", func() {
+			namespace := newNamespace(map[string]string{"team": "platform"})
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Status: labelsv1alpha1.NamespacelabelStatus{
+					AppliedLabels: map[string]string{"team": "platform"},
+				},
+			}
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the namespace and the applied labels were left untouched")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(HaveKeyWithValue("team", "platform"))
+
+			By("Verifying the SuspiciousEmptySpec condition and warning event")
+			Expect(meta.IsStatusConditionTrue(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionSuspiciousEmptySpec))).To(BeTrue())
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("SuspiciousEmptySpec")))
+		})
+
+		It("should reconcile a confirmed empty spec", func() {
+			namespace := newNamespace(map[string]string{"team": "platform"})
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        NamespaceLabelCR,
+					Namespace:   NamespaceName,
+					Annotations: map[string]string{labels.ConfirmEmptySpecAnnotation: "true"},
+				},
+				Status: labelsv1alpha1.NamespacelabelStatus{
+					AppliedLabels: map[string]string{"team": "platform"},
+				},
+			}
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the empty spec was reconciled without the guard condition")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(BeEmpty())
+			Expect(meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionSuspiciousEmptySpec))).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionLabelsApplied))).To(BeTrue())
+		})
+	})
+
+	Context("Status write failures", func() {
+		It("should record the applied labels and restore status on the next reconcile without re-applying", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform"},
+			})
+			failStatus := true
+			reconciler, recorder := newInterceptedTestReconciler(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					if failStatus {
+						return errors.NewServiceUnavailable("status writes unavailable")
+					}
+					return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+				},
+			}, namespace, labelsCR)
+
+			By("Reconciling while status writes fail")
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).To(HaveOccurred())
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("AppliedLabels")))
+
+			By("Verifying the applied labels were recorded on the CR")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Annotations).To(HaveKeyWithValue(labels.PendingStatusAnnotation, `{"team":"platform"}`))
+			Expect(labelsCR.Status.AppliedLabels).To(BeEmpty())
+
+			By("Reconciling once status writes recover")
+			failStatus = false
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying status was restored and the annotation cleared")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(HaveKeyWithValue("team", "platform"))
+			Expect(labelsCR.Annotations).NotTo(HaveKey(labels.PendingStatusAnnotation))
+			Expect(meta.IsStatusConditionFalse(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionDuplicateLabels))).To(BeTrue())
+
+			By("Verifying the labels were not applied again")
+			Consistently(recorder.Events).ShouldNot(Receive(ContainSubstring("AppliedLabels")))
+		})
+	})
+
+	Context("Team ownership", func() {
+		newTeam := func() *unstructured.Unstructured {
+			team := &unstructured.Unstructured{}
+			team.SetAPIVersion("teams.example.com/v1")
+			team.SetKind("Team")
+			team.SetNamespace(NamespaceName)
+			team.SetName("platform")
+			team.SetUID(types.UID("team-uid"))
+			return team
+		}
+
+		It("should make the team an owner so the Namespacelabel is garbage-collected with it", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:  map[string]string{"app": "web"},
+				TeamRef: &labelsv1alpha1.TeamReference{APIVersion: "teams.example.com/v1", Kind: "Team", Name: "platform"},
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR, newTeam())
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the owner reference points at the team")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.OwnerReferences).To(HaveLen(1))
+			Expect(labelsCR.OwnerReferences[0].Kind).To(Equal("Team"))
+			Expect(labelsCR.OwnerReferences[0].Name).To(Equal("platform"))
+			Expect(labelsCR.OwnerReferences[0].UID).To(Equal(types.UID("team-uid")))
+		})
+
+		It("should apply the team's name under the configured label key", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"app": "web"},
+				TeamRef: &labelsv1alpha1.TeamReference{
+					APIVersion: "teams.example.com/v1", Kind: "Team", Name: "platform", LabelKey: "team",
+				},
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR, newTeam())
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the team-derived label was applied alongside spec.labels")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("app", "web"))
+		})
+
+		It("should fail the reconcile when the team does not exist", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:  map[string]string{"app": "web"},
+				TeamRef: &labelsv1alpha1.TeamReference{APIVersion: "teams.example.com/v1", Kind: "Team", Name: "missing"},
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to get team"))
+		})
+	})
+
+	Context("Active conditions", func() {
+		It("should list exactly the conditions that are True after skipping a protected label", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform", "protected-label": "value"},
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying ActiveConditions matches the True conditions")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.ActiveConditions).To(Equal([]string{
+				string(labelsv1alpha1.ConditionConfigLoaded),
+				string(labelsv1alpha1.ConditionLabelsApplied),
+				string(labelsv1alpha1.ConditionLabelsSkipped),
+			}))
+			for _, condition := range labelsCR.Status.Conditions {
+				if condition.Status == metav1.ConditionTrue {
+					Expect(labelsCR.Status.ActiveConditions).To(ContainElement(condition.Type))
+				}
+			}
+		})
+	})
+
+	Context("Cleanup verification", func() {
+		It("should retry cleanup when a racing writer re-adds a label", func() {
+			namespace := newNamespace(map[string]string{"team": "platform", "app": "web"})
+			deletedAt := metav1.Now()
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              NamespaceLabelCR,
+					Namespace:         NamespaceName,
+					Finalizers:        []string{"namespacelabels.finalizers.dana.io"},
+					DeletionTimestamp: &deletedAt,
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"team": "platform"},
+				},
+				Status: labelsv1alpha1.NamespacelabelStatus{AppliedLabels: map[string]string{"team": "platform"}},
+			}
+			raced := false
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if err := c.Patch(ctx, obj, patch, opts...); err != nil {
+						return err
+					}
+					if _, ok := obj.(*corev1.Namespace); !ok || raced {
+						return nil
+					}
+					raced = true
+					racing := &corev1.Namespace{}
+					Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), racing)).To(Succeed())
+					racing.Labels["team"] = "platform"
+					return c.Update(ctx, racing)
+				},
+			}, namespace, labelsCR)
+
+			By("Reconciling the deleted Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(raced).To(BeTrue())
+
+			By("Verifying the re-added label was removed and other labels kept")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("team"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("app", "web"))
+
+			By("Verifying the finalizer was removed")
+			err = reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		DescribeTable("should skip label removal when the namespace is gone",
+			func(namespaceObjs ...client.Object) {
+				deletedAt := metav1.Now()
+				labelsCR := &labelsv1alpha1.Namespacelabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              NamespaceLabelCR,
+						Namespace:         NamespaceName,
+						Finalizers:        []string{"namespacelabels.finalizers.dana.io"},
+						DeletionTimestamp: &deletedAt,
+					},
+					Spec: labelsv1alpha1.NamespacelabelSpec{
+						Labels: map[string]string{"team": "platform"},
+					},
+				}
+				namespaceWrites := 0
+				reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+					Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							namespaceWrites++
+						}
+						return c.Update(ctx, obj, opts...)
+					},
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							namespaceWrites++
+						}
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}, append(namespaceObjs, labelsCR)...)
+
+				By("Reconciling the deleted Namespacelabel CR")
+				_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+				Expect(err).NotTo(HaveOccurred())
+
+				By("Verifying the namespace was not written and the finalizer was removed")
+				Expect(namespaceWrites).To(BeZero())
+				err = reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			},
+			Entry("namespace already deleted"),
+			Entry("namespace terminating", &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:              NamespaceName,
+				Labels:            map[string]string{"team": "platform"},
+				Finalizers:        []string{"kubernetes"},
+				DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			}}),
+		)
+	})
+
+	Context("Delayed apply", func() {
+		It("should requeue without applying while applyAfter is in the future", func() {
+			namespace := newNamespace(nil)
+			applyAfter := metav1.NewTime(time.Now().Add(time.Hour))
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:     map[string]string{"team": "platform"},
+				ApplyAfter: &applyAfter,
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			result, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 59*time.Minute))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", time.Hour))
+
+			By("Verifying nothing was applied and the Scheduled condition is set")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("team"))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionScheduled))).To(BeTrue())
+		})
+
+		It("should apply immediately when applyAfter is in the past", func() {
+			namespace := newNamespace(nil)
+			applyAfter := metav1.NewTime(time.Now().Add(-time.Hour))
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:     map[string]string{"team": "platform"},
+				ApplyAfter: &applyAfter,
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			result, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			By("Verifying the label was applied and the Scheduled condition cleared")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionScheduled))).To(BeTrue())
+		})
+	})
+
+	Context("Targeting a namespace by selector", func() {
+		var labelsCR *labelsv1alpha1.Namespacelabel
+
+		BeforeEach(func() {
+			labelsCR = newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform"},
+				NamespaceLabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"env": "prod"},
+				},
+			})
+		})
+
+		It("should label the single namespace matching the selector", func() {
+			own := newNamespace(nil)
+			target := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}}
+			reconciler, _ := newTestReconciler(own, target, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying only the matching namespace was labelled")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "prod"}, target)).To(Succeed())
+			Expect(target.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, own)).To(Succeed())
+			Expect(own.Labels).NotTo(HaveKey("team"))
+
+			By("Verifying the resolved target is reported")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.TargetNamespace).To(Equal("prod"))
+			Expect(meta.IsStatusConditionTrue(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionTargetResolved))).To(BeTrue())
+		})
+
+		It("should apply nothing when the selector matches several namespaces", func() {
+			own := newNamespace(nil)
+			first := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod-a", Labels: map[string]string{"env": "prod"}}}
+			second := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod-b", Labels: map[string]string{"env": "prod"}}}
+			reconciler, _ := newTestReconciler(own, first, second, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying neither namespace was labelled")
+			for _, namespace := range []*corev1.Namespace{first, second} {
+				Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)).To(Succeed())
+				Expect(namespace.Labels).NotTo(HaveKey("team"))
+			}
+
+			By("Verifying the ambiguous target is reported")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionTargetResolved))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonAmbiguousTarget)))
+			Expect(condition.Message).To(ContainSubstring("prod-a"))
+		})
+
+	})
+
+	Context("Managed labels metric", func() {
+		managedLabels := func(namespace string, namespaceLabel client.Object) float64 {
+			var metric dto.Metric
+			Expect(metrics.ManagedLabels.WithLabelValues(namespace, client.ObjectKeyFromObject(namespaceLabel).String()).Write(&metric)).To(Succeed())
+			return metric.GetGauge().GetValue()
+		}
+
+		It("should track the applied labels and reset once they are cleaned up", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform", "app": "web"},
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(managedLabels(NamespaceName, labelsCR)).To(Equal(2.0))
+
+			By("Deleting the Namespacelabel CR and reconciling the cleanup")
+			Expect(reconciler.Delete(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, requestFor(labelsCR))
+			Expect(managedLabels(NamespaceName, labelsCR)).To(BeZero())
+		})
+
+		It("should track each Namespacelabel targeting a namespace separately", func() {
+			namespace := newNamespace(nil)
+			first := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform", "app": "web"}},
+			}
+			second := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"env": "prod"}},
+			}
+			reconciler, _ := newTestReconciler(namespace, first, second)
+
+			By("Reconciling both Namespacelabel CRs")
+			for _, labelsCR := range []client.Object{first, second} {
+				mustReconcile(reconciler, requestFor(labelsCR))
+			}
+			Expect(managedLabels(NamespaceName, first)).To(Equal(2.0))
+			Expect(managedLabels(NamespaceName, second)).To(Equal(1.0))
+
+			By("Deleting the first Namespacelabel CR and reconciling the cleanup")
+			Expect(reconciler.Delete(ctx, first)).To(Succeed())
+			mustReconcile(reconciler, requestFor(first))
+			Expect(managedLabels(NamespaceName, first)).To(BeZero())
+			Expect(managedLabels(NamespaceName, second)).To(Equal(1.0))
+		})
+	})
+
+	Context("Orphaned conditions", func() {
+		It("should prune conditions of unknown types", func() {
+			namespace := newNamespace(nil)
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"team": "platform"},
+				},
+				Status: labelsv1alpha1.NamespacelabelStatus{
+					Conditions: []metav1.Condition{{
+						Type:               "DeprecatedFeature",
+						Status:             metav1.ConditionTrue,
+						Reason:             "Legacy",
+						LastTransitionTime: metav1.Now(),
+					}},
+				},
+			}
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the unknown condition was pruned and known ones kept")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(meta.FindStatusCondition(labelsCR.Status.Conditions, "DeprecatedFeature")).To(BeNil())
+			Expect(labelsCR.Status.ActiveConditions).NotTo(ContainElement("DeprecatedFeature"))
+			Expect(meta.IsStatusConditionTrue(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionLabelsApplied))).To(BeTrue())
+		})
+	})
+
+	Context("Immutable labels", func() {
+		It("should revert an immutable label changed out-of-band", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:        map[string]string{"team": "platform"},
+				ImmutableKeys: []string{"team"},
+			})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Changing the immutable label on the namespace out-of-band")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			namespace.Labels["team"] = "payments"
+			Expect(reconciler.Update(ctx, namespace)).To(Succeed())
+
+			By("Reconciling again")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the label was reverted and an event emitted")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("ImmutableReverted")))
+		})
+
+		It("should leave an immutable key the namespace already had from someone else alone", func() {
+			namespace := newNamespace(map[string]string{"team": "payments"})
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:        map[string]string{"team": "platform"},
+				ImmutableKeys: []string{"team"},
+			})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+			request := requestFor(labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the foreign label was treated as a duplicate")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "payments"))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).NotTo(HaveKey("team"))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("DuplicateLabelSkipped")))
+
+			By("Deleting the Namespacelabel CR and reconciling the cleanup")
+			Expect(reconciler.Delete(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+
+			By("Verifying the foreign label survived")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "payments"))
+		})
+	})
+
+	Context("Central event mirroring", func() {
+		It("should mirror events into the central namespace", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform"},
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			captured := &namespaceRecorder{}
+			reconciler.Recorder = events.NewMirroringRecorder(captured, scheme.Scheme, "monitoring", logr.Discard())
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the event was recorded on the CR and mirrored into the central namespace")
+			Expect(captured.events).To(ContainElement(ContainSubstring(NamespaceName + " AppliedLabels")))
+			Expect(captured.events).To(ContainElement(SatisfyAll(
+				HavePrefix("monitoring AppliedLabels"),
+				ContainSubstring(NamespaceName+"/"+NamespaceLabelCR+": Applied labels"),
+			)))
+		})
+	})
+
+	Context("Labels from a ConfigMap", func() {
+		var (
+			namespace *corev1.Namespace
+			configMap *corev1.ConfigMap
+			labelsCR  *labelsv1alpha1.Namespacelabel
+		)
+
+		BeforeEach(func() {
+			namespace = newNamespace(nil)
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "namespace-labels", Namespace: NamespaceName},
+				Data:       map[string]string{"team": "platform", "cost-center": "1234"},
+			}
+			labelsCR = newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:     map[string]string{"cost-center": "5678"},
+				LabelsFrom: &labelsv1alpha1.ConfigMapReference{Name: "namespace-labels"},
+			})
+		})
+
+		It("should merge the ConfigMap data with spec.labels taking precedence", func() {
+			reconciler, _ := newTestReconciler(namespace, configMap, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the merged labels were applied")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("cost-center", "5678"))
+		})
+
+		It("should skip ConfigMap keys with the operator's reserved prefix", func() {
+			configMap.Data["labels.dana.io/opt-out"] = "true"
+			reconciler, recorder := newTestReconciler(namespace, configMap, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the reserved key was skipped and reported")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("labels.dana.io/opt-out"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.SkipReasons).To(HaveKeyWithValue("labels.dana.io/opt-out", labelsv1alpha1.SkipReasonReservedPrefix))
+			events := drainEvents(recorder)
+			Expect(events).To(ContainElement(ContainSubstring("ReservedPrefix")))
+		})
+
+		It("should pick up ConfigMap changes and enqueue the referencing Namespacelabel", func() {
+			reconciler, _ := newTestReconciler(namespace, configMap, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Changing the ConfigMap")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+			configMap.Data["team"] = "payments"
+			Expect(reconciler.Update(ctx, configMap)).To(Succeed())
+
+			By("Verifying the change maps to the Namespacelabel")
+			requests := reconciler.enqueueRequestsFromConfigMap(ctx, configMap)
+			Expect(requests).To(ConsistOf(requestFor(labelsCR)))
+
+			By("Reconciling again and verifying the new value was applied")
+			mustReconcile(reconciler, requests[0])
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "payments"))
+		})
+
+		It("should fail the reconcile when the ConfigMap does not exist", func() {
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to get labels ConfigMap"))
+		})
+	})
+
+	Context("Dry-run plan", func() {
+		It("should record the plan as an annotation without touching the namespace", func() {
+			namespace := newNamespace(map[string]string{"env": "prod"})
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform", "env": "staging", "protected-label": "value"},
+				DryRun: true,
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the namespace was not changed")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(Equal(map[string]string{"env": "prod"}))
+
+			By("Verifying the plan annotation")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Annotations[labels.DryRunPlanAnnotation]).To(MatchJSON(
+				`{"apply":{"team":"platform"},"skipped":{"protected-label":"Protected"},"duplicates":{"env":"staging"}}`))
+
+			By("Disabling dry-run and verifying the annotation is cleared")
+			labelsCR.Spec.DryRun = false
+			Expect(reconciler.Update(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, requestFor(labelsCR))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Annotations).NotTo(HaveKey(labels.DryRunPlanAnnotation))
+		})
+	})
+
+	Context("Protected label exemption", func() {
+		It("should apply protected labels when the CR is exempt", func() {
+			namespace := newNamespace(nil)
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        NamespaceLabelCR,
+					Namespace:   NamespaceName,
+					Annotations: map[string]string{labels.ExemptProtectedAnnotation: "true"},
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"protected-label": "value"},
+				},
+			}
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the protected label was applied")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("protected-label", "value"))
+		})
+
+		It("should record the protected keys applied under the exemption for auditing", func() {
+			namespace := newNamespace(nil)
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        NamespaceLabelCR,
+					Namespace:   NamespaceName,
+					Annotations: map[string]string{labels.ExemptProtectedAnnotation: "true"},
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"protected-label": "value", "team": "platform"},
+				},
+			}
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			request := requestFor(labelsCR)
+
+			By("Reconciling the exempt Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the condition lists only the overridden protected key")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionProtectedOverrideUsed))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonProtectedLabelsOverridden)))
+			Expect(condition.Message).To(ContainSubstring("protected-label"))
+			Expect(condition.Message).NotTo(ContainSubstring("team"))
+
+			By("Dropping the protected key and verifying the condition is removed")
+			labelsCR.Spec.Labels = map[string]string{"team": "platform"}
+			Expect(reconciler.Update(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionProtectedOverrideUsed))).To(BeNil())
+		})
+	})
+
+	Context("Apply results", func() {
+		var (
+			namespace *corev1.Namespace
+			labelsCR  *labelsv1alpha1.Namespacelabel
+			plan      *labelPlan
+		)
+
+		BeforeEach(func() {
+			namespace = newNamespace(map[string]string{"app": "web"})
+			labelsCR = newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"app": "web", "team": "platform"},
+			})
+			plan = &labelPlan{updated: map[string]string{"app": "web", "team": "platform"}}
+		})
+
+		It("should write the namespace when a label changes", func() {
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			result, err := reconciler.applyLabels(ctx, namespace, labelsCR, plan, []string{"app", "team"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.wroteNamespace).To(BeTrue())
+			Expect(result.changed).To(Equal([]string{"team=platform"}))
+			Expect(result.unchanged).To(Equal(1))
+		})
+
+		It("should not write the namespace when every label already has its value", func() {
+			namespace.Labels["team"] = "platform"
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			result, err := reconciler.applyLabels(ctx, namespace, labelsCR, plan, []string{"app", "team"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.wroteNamespace).To(BeFalse())
+			Expect(result.changed).To(BeEmpty())
+			Expect(result.unchanged).To(Equal(2))
+		})
+
+		It("should write the namespace when only the apply timestamp changes", func() {
+			namespace.Labels["team"] = "platform"
+			labelsCR.Spec.RecordApplyTimestamp = true
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			result, err := reconciler.applyLabels(ctx, namespace, labelsCR, plan, []string{"app", "team"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.wroteNamespace).To(BeTrue())
+			Expect(result.changed).To(BeEmpty())
+		})
+
+		It("should not write the namespace on a steady-state reconcile", func() {
+			namespaceWrites := 0
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok {
+						namespaceWrites++
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}, namespace, labelsCR)
+
+			By("Reconciling twice")
+			for range 2 {
+				mustReconcile(reconciler, requestFor(labelsCR))
+			}
+
+			By("Verifying only the first reconcile wrote the namespace")
+			Expect(namespaceWrites).To(Equal(1))
+		})
+
+		It("should keep labels another controller set between the read and the write, on apply and on cleanup", func() {
+			concurrentWrite := true
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok && concurrentWrite {
+						concurrentWrite = false
+						other := &corev1.Namespace{}
+						if err := c.Get(ctx, client.ObjectKeyFromObject(obj), other); err != nil {
+							return err
+						}
+						other.Labels["quota"] = "high"
+						if err := c.Update(ctx, other); err != nil {
+							return err
+						}
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}, namespace, labelsCR)
+
+			By("Reconciling while another controller labels the namespace")
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying both controllers' labels are on the namespace")
+			updatedNamespace := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(namespace), updatedNamespace)).To(Succeed())
+			Expect(updatedNamespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(updatedNamespace.Labels).To(HaveKeyWithValue("quota", "high"))
+
+			By("Deleting the Namespacelabel while another controller labels the namespace during cleanup")
+			concurrentWrite = true
+			Expect(reconciler.Delete(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, requestFor(labelsCR))
+			Expect(concurrentWrite).To(BeFalse())
+
+			By("Verifying the cleanup removed only the Namespacelabel's labels")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(namespace), updatedNamespace)).To(Succeed())
+			Expect(updatedNamespace.Labels).NotTo(HaveKey("team"))
+			Expect(updatedNamespace.Labels).To(HaveKeyWithValue("quota", "high"))
+		})
+	})
+
+	Context("Namespaces opted out of management", func() {
+		var namespace *corev1.Namespace
+
+		BeforeEach(func() {
+			namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        NamespaceName,
+				Labels:      map[string]string{"team": "platform"},
+				Annotations: map[string]string{labels.OptOutAnnotation: "true"},
+			}}
+		})
+
+		It("should apply nothing and report OptedOut", func() {
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"app": "web"},
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying nothing was applied and the condition is set")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("app"))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionOptedOut))).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionLabelsApplied))).To(BeTrue())
+		})
+
+		It("should leave the namespace labels in place on cleanup", func() {
+			deletedAt := metav1.Now()
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              NamespaceLabelCR,
+					Namespace:         NamespaceName,
+					Finalizers:        []string{"namespacelabels.finalizers.dana.io"},
+					DeletionTimestamp: &deletedAt,
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"team": "platform"},
+				},
+			}
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the deleted Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the labels were kept and the finalizer removed")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			err = reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("Event rate limiting", func() {
+		It("should throttle events from a flapping Namespacelabel", func() {
+			namespace := newNamespace(nil)
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName, UID: types.UID("flapping-uid")},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"protected-label": "value"},
+				},
+			}
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+			reconciler.Recorder = events.NewRateLimitedRecorder(recorder, 2, logr.Discard())
+
+			By("Reconciling rapidly, each reconcile emitting a ProtectedLabelSkipped event")
+			for range 5 {
+				mustReconcile(reconciler, requestFor(labelsCR))
+			}
+
+			By("Verifying only the burst of events was recorded")
+			Expect(recorder.Events).To(HaveLen(2))
+			var dropped dto.Metric
+			Expect(metrics.DroppedEvents.WithLabelValues(NamespaceName).Write(&dropped)).To(Succeed())
+			Expect(dropped.GetCounter().GetValue()).To(BeNumerically(">=", 3))
+		})
+	})
+	Context("Label aliases", func() {
+		It("should apply aliases with the source value and remove them on cleanup", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:  map[string]string{"team": "platform", "owner": "alice"},
+				Aliases: map[string][]string{"team": {"app.kubernetes.io/team", "owner"}},
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Labels).To(SatisfyAll(
+				HaveKeyWithValue("team", "platform"),
+				HaveKeyWithValue("app.kubernetes.io/team", "platform"),
+				HaveKeyWithValue("owner", "alice"),
+			))
+
+			By("Deleting the Namespacelabel CR and reconciling the cleanup")
+			Expect(reconciler.Delete(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Labels).NotTo(HaveKey("team"))
+			Expect(updated.Labels).NotTo(HaveKey("app.kubernetes.io/team"))
+			Expect(updated.Labels).NotTo(HaveKey("owner"))
+		})
+
+		It("should skip protected and duplicate aliases individually", func() {
+			namespace := newNamespace(map[string]string{"tier": "gold"})
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:  map[string]string{"team": "platform"},
+				Aliases: map[string][]string{"team": {"protected-label", "tier", "squad"}},
+			})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Labels).To(SatisfyAll(
+				HaveKeyWithValue("team", "platform"),
+				HaveKeyWithValue("squad", "platform"),
+				HaveKeyWithValue("tier", "gold"),
+				Not(HaveKey("protected-label")),
+			))
+
+			events := drainEvents(recorder)
+			Expect(events).To(ContainElement(ContainSubstring("ProtectedLabelSkipped")))
+			Expect(events).To(ContainElement(ContainSubstring("DuplicateLabelSkipped")))
+		})
+	})
+	Context("Protected labels configuration health", func() {
+		It("should report ConfigLoaded True when the configuration loads", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			updated := &labelsv1alpha1.Namespacelabel{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), updated)).To(Succeed())
+			condition := meta.FindStatusCondition(updated.Status.Conditions, string(labelsv1alpha1.ConditionConfigLoaded))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonProtectedConfigLoaded)))
+		})
+
+		It("should report ConfigLoaded False and apply nothing when the configuration is malformed", func() {
+			DeferCleanup(os.Setenv, labels.ProtectedLabelsEnv, os.Getenv(labels.ProtectedLabelsEnv))
+			Expect(os.Setenv(labels.ProtectedLabelsEnv, "{not json")).To(Succeed())
+
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).To(HaveOccurred())
+
+			updated := &labelsv1alpha1.Namespacelabel{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), updated)).To(Succeed())
+			condition := meta.FindStatusCondition(updated.Status.Conditions, string(labelsv1alpha1.ConditionConfigLoaded))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonProtectedConfigInvalid)))
+			Expect(condition.Message).To(ContainSubstring("failed to parse protected labels"))
+
+			unchanged := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, unchanged)).To(Succeed())
+			Expect(unchanged.Labels).NotTo(HaveKey("team"))
+		})
+	})
+	Context("Mirroring applied labels to annotations", func() {
+		It("should annotate each applied label and remove the annotations on cleanup", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:              map[string]string{"team": "platform", "app.kubernetes.io/part-of": "shop", "protected-label": "x"},
+				MirrorToAnnotations: true,
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: NamespaceLabelCR, Namespace: NamespaceName}}
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Annotations).To(SatisfyAll(
+				HaveKeyWithValue("labels.dana.io/applied.team", "platform"),
+				HaveKeyWithValue("labels.dana.io/applied.app.kubernetes.io_part-of", "shop"),
+				Not(HaveKey("labels.dana.io/applied.protected-label")),
+			))
+
+			By("Deleting the Namespacelabel CR and reconciling the cleanup")
+			Expect(reconciler.Delete(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Annotations).NotTo(HaveKey("labels.dana.io/applied.team"))
+			Expect(updated.Annotations).NotTo(HaveKey("labels.dana.io/applied.app.kubernetes.io_part-of"))
+		})
+
+		It("should remove the annotations once mirroring is turned off", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:              map[string]string{"team": "platform"},
+				MirrorToAnnotations: true,
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: NamespaceLabelCR, Namespace: NamespaceName}}
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Turning mirroring off")
+			current := &labelsv1alpha1.Namespacelabel{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, current)).To(Succeed())
+			current.Spec.MirrorToAnnotations = false
+			Expect(reconciler.Update(ctx, current)).To(Succeed())
+			mustReconcile(reconciler, request)
+
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(updated.Annotations).NotTo(HaveKey("labels.dana.io/applied.team"))
+		})
+
+		It("should remove the annotation of a label dropped from the spec", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:              map[string]string{"team": "platform", "env": "prod"},
+				MirrorToAnnotations: true,
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: NamespaceLabelCR, Namespace: NamespaceName}}
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Dropping a label while mirroring stays on")
+			current := &labelsv1alpha1.Namespacelabel{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, current)).To(Succeed())
+			current.Spec.Labels = map[string]string{"team": "platform"}
+			Expect(reconciler.Update(ctx, current)).To(Succeed())
+			mustReconcile(reconciler, request)
+
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue("labels.dana.io/applied.team", "platform"))
+			Expect(updated.Annotations).NotTo(HaveKey("labels.dana.io/applied.env"))
+		})
+
+		It("should keep the annotation key of a long label key valid", func() {
+			longKey := "example.com/" + strings.Repeat("a", 50)
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:              map[string]string{longKey: "x", "example.com/" + strings.Repeat("a", 49) + "b": "y"},
+				MirrorToAnnotations: true,
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue(labels.MirrorAnnotationKey(longKey), "x"))
+			mirrors := 0
+			for key := range updated.Annotations {
+				if strings.HasPrefix(key, labels.MirrorAnnotationPrefix) {
+					Expect(validation.IsQualifiedName(key)).To(BeEmpty())
+					mirrors++
+				}
+			}
+			Expect(mirrors).To(Equal(2))
+		})
+	})
+	Context("Reconcile report on the namespace", func() {
+		var (
+			namespace *corev1.Namespace
+			labelsCR  *labelsv1alpha1.Namespacelabel
+		)
+
+		BeforeEach(func() {
+			namespace = newNamespace(nil)
+			labelsCR = newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform", "protected-label": "x"},
+			})
+			DeferCleanup(os.Setenv, EventsOnNamespaceEnv, os.Getenv(EventsOnNamespaceEnv))
+		})
+
+		It("should record a summary event against the namespace when enabled", func() {
+			Expect(os.Setenv(EventsOnNamespaceEnv, "true")).To(Succeed())
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			captured := &objectRecorder{}
+			reconciler.Recorder = captured
+
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			Expect(captured.events).To(ContainElement(
+				"Namespace/" + NamespaceName + " NamespacelabelReconciled Namespacelabel " + NamespaceName + "/" + NamespaceLabelCR + " reconciled: 1 applied, 1 skipped, 0 duplicates",
+			))
+		})
+
+		It("should not record events against the namespace when disabled", func() {
+			Expect(os.Setenv(EventsOnNamespaceEnv, "")).To(Succeed())
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			captured := &objectRecorder{}
+			reconciler.Recorder = captured
+
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			Expect(captured.events).NotTo(ContainElement(HavePrefix("Namespace/")))
+		})
+	})
+	Context("Value transforms", func() {
+		DescribeTable("should transform the value before applying it",
+			func(transform labelsv1alpha1.LabelTransform, value, expected string) {
+				namespace := newNamespace(nil)
+				labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+					Labels:     map[string]string{"team": value},
+					Transforms: map[string]labelsv1alpha1.LabelTransform{"team": transform},
+				})
+				reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+				mustReconcile(reconciler, requestFor(labelsCR))
+
+				updated := &corev1.Namespace{}
+				Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+				Expect(updated.Labels).To(HaveKeyWithValue("team", expected))
+			},
+			Entry("upper", labelsv1alpha1.TransformUpper, "Platform", "PLATFORM"),
+			Entry("lower", labelsv1alpha1.TransformLower, "Platform", "platform"),
+			Entry("trim", labelsv1alpha1.TransformTrim, "  platform ", "platform"),
+			Entry("slug", labelsv1alpha1.TransformSlug, "--Platform Team_2.0!", "platform-team-2-0"),
+		)
+
+	})
+	Context("Maintenance window", func() {
+		var (
+			namespace *corev1.Namespace
+			labelsCR  *labelsv1alpha1.Namespacelabel
+		)
+
+		BeforeEach(func() {
+			namespace = newNamespace(nil)
+			labelsCR = newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform"},
+				MaintenanceWindow: &labelsv1alpha1.MaintenanceWindow{
+					Start: "09:00",
+					End:   "17:00",
+					Days:  []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+				},
+			})
+		})
+
+		It("should apply labels inside the window", func() {
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			// Monday 10:00 UTC.
+			reconciler.Clock = clocktesting.NewFakePassiveClock(time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC))
+
+			result, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionOutsideWindow))).To(BeTrue())
+		})
+
+		It("should defer applying labels until the window opens", func() {
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			// Saturday 10:00 UTC; the window next opens on Monday at 09:00.
+			reconciler.Clock = clocktesting.NewFakePassiveClock(time.Date(2024, time.June, 8, 10, 0, 0, 0, time.UTC))
+
+			result, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(47 * time.Hour))
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("team"))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionOutsideWindow))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("2024-06-10T09:00:00Z"))
+		})
+
+		It("should still clean up outside the window", func() {
+			namespace.Labels = map[string]string{"team": "platform"}
+			labelsCR.Finalizers = []string{"namespacelabels.finalizers.dana.io"}
+			labelsCR.Status.AppliedLabels = map[string]string{"team": "platform"}
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			reconciler.Clock = clocktesting.NewFakePassiveClock(time.Date(2024, time.June, 8, 10, 0, 0, 0, time.UTC))
+
+			Expect(reconciler.Delete(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("team"))
+		})
+	})
+	Context("Injectable clock", func() {
+		It("should take condition times, the apply timestamp and applyAfter from the clock", func() {
+			now := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+			applyAfter := metav1.NewTime(now.Add(time.Hour))
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:               map[string]string{"team": "platform"},
+				RecordApplyTimestamp: true,
+				ApplyAfter:           &applyAfter,
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			fakeClock := clocktesting.NewFakePassiveClock(now)
+			reconciler.Clock = fakeClock
+			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: NamespaceLabelCR, Namespace: NamespaceName}}
+
+			By("Reconciling before applyAfter")
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Hour))
+
+			By("Moving the clock past applyAfter and reconciling again")
+			applied := now.Add(2 * time.Hour)
+			fakeClock.SetTime(applied)
+			result, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Annotations).To(HaveKeyWithValue(labels.LastAppliedAnnotation, "2024-06-03T12:00:00Z"))
+
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionLabelsApplied))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.LastTransitionTime.Time).To(BeTemporally("==", applied))
+		})
+	})
+	Context("Verbose events", func() {
+		DescribeTable("should list or count the applied labels in the AppliedLabels event",
+			func(verbose bool, expected string) {
+				namespace := newNamespace(nil)
+				labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+					Labels:        map[string]string{"app": "web", "team": "platform"},
+					VerboseEvents: verbose,
+				})
+				reconciler, recorder := newTestReconciler(namespace, labelsCR)
+
+				mustReconcile(reconciler, requestFor(labelsCR))
+
+				events := drainEvents(recorder)
+				Expect(events).To(ContainElement(expected))
+			},
+			Entry("verbose", true, "Normal AppliedLabels Applied labels in order: app=web, team=platform"),
+			Entry("summary", false, "Normal AppliedLabels Applied labels: 2 added or changed"),
+		)
+	})
+	Context("Outgoing notifications", func() {
+		var (
+			namespace *corev1.Namespace
+			labelsCR  *labelsv1alpha1.Namespacelabel
+		)
+
+		BeforeEach(func() {
+			namespace = newNamespace(nil)
+			labelsCR = newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+		})
+
+		It("should post a notification after apply and after cleanup", func() {
+			received := make(chan notify.Notification, 10)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var notification notify.Notification
+				if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				received <- notification
+			}))
+			DeferCleanup(server.Close)
+
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			reconciler.Notifier = notify.NewNotifier(server.URL, reconciler.Log)
+			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: NamespaceLabelCR, Namespace: NamespaceName}}
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			var notification notify.Notification
+			Eventually(received).Should(Receive(&notification))
+			Expect(notification.Event).To(Equal(notify.EventApplied))
+			Expect(notification.Namespacelabel).To(Equal(NamespaceName + "/" + NamespaceLabelCR))
+			Expect(notification.Namespace).To(Equal(NamespaceName))
+			Expect(notification.Labels).To(Equal(map[string]string{"team": "platform"}))
+
+			By("Deleting the Namespacelabel CR and reconciling the cleanup")
+			Expect(reconciler.Delete(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+
+			Eventually(received).Should(Receive(&notification))
+			Expect(notification.Event).To(Equal(notify.EventCleanedUp))
+			Expect(notification.Namespace).To(Equal(NamespaceName))
+		})
+
+		It("should only notify effective changes with notifyOnChangeOnly", func() {
+			received := make(chan notify.Notification, 10)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var notification notify.Notification
+				if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				received <- notification
+			}))
+			DeferCleanup(server.Close)
+
+			labelsCR.Spec.NotifyOnChangeOnly = true
+			labelsCR.Spec.RecordApplyTimestamp = true
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			reconciler.Notifier = notify.NewNotifier(server.URL, reconciler.Log)
+			clock := clocktesting.NewFakePassiveClock(time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC))
+			reconciler.Clock = clock
+			request := requestFor(labelsCR)
+
+			By("Reconciling the Namespacelabel CR for the first time")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			var notification notify.Notification
+			Eventually(received).Should(Receive(&notification))
+			Expect(notification.Event).To(Equal(notify.EventApplied))
+
+			By("Reconciling again, which changes nothing and keeps the apply timestamp")
+			clock.SetTime(clock.Now().Add(time.Minute))
+			mustReconcile(reconciler, request)
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue(labels.LastAppliedAnnotation, "2024-06-03T10:00:00Z"))
+			Consistently(received, 200*time.Millisecond).ShouldNot(Receive())
+
+			By("Changing a label value")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			labelsCR.Spec.Labels["team"] = "payments"
+			Expect(reconciler.Update(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+			Eventually(received).Should(Receive(&notification))
+			Expect(notification.Labels).To(Equal(map[string]string{"team": "payments"}))
+		})
+
+		It("should retry a failing endpoint without failing the reconcile", func() {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			DeferCleanup(server.Close)
+
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			notifier := notify.NewNotifier(server.URL, reconciler.Log)
+			notifier.Backoff = 10 * time.Millisecond
+			reconciler.Notifier = notifier
+
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Eventually(attempts.Load).Should(Equal(int32(notifier.Attempts)))
+			Consistently(attempts.Load, 100*time.Millisecond).Should(Equal(int32(notifier.Attempts)))
+		})
+
+		It("should give up on an attempt once the timeout passes", func() {
+			release := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-release:
+				case <-r.Context().Done():
+				}
+			}))
+			DeferCleanup(server.Close)
+			DeferCleanup(func() { close(release) })
+
+			notifier := notify.NewNotifier(server.URL, logr.Discard())
+			notifier.Timeout = 50 * time.Millisecond
+			notifier.Attempts = 1
+
+			err := notifier.Send(ctx, notify.Notification{Event: notify.EventApplied})
+			Expect(err).To(MatchError(ContainSubstring("notification not delivered after 1 attempts")))
+		})
+	})
+	Context("Applied label checksum", func() {
+		It("should record the checksum and report a mismatch once a managed label is changed", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform", "app": "web"}})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: NamespaceLabelCR, Namespace: NamespaceName}}
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedChecksum).To(Equal(labels.Checksum(map[string]string{"team": "platform", "app": "web"})))
+
+			By("Reconciling again without changes and verifying no mismatch is reported")
+			mustReconcile(reconciler, request)
+			for len(recorder.Events) > 0 {
+				Expect(<-recorder.Events).NotTo(ContainSubstring("ChecksumMismatch"))
+			}
+
+			By("Changing a managed label out-of-band")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			namespace.Labels["team"] = "intruder"
+			Expect(reconciler.Update(ctx, namespace)).To(Succeed())
+
+			By("Reconciling and verifying the mismatch is reported and corrected")
+			mustReconcile(reconciler, request)
+			events := drainEvents(recorder)
+			Expect(events).To(ContainElement(ContainSubstring("ChecksumMismatch")))
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+		})
+	})
+	Context("Deny ConfigMap", func() {
+		var (
+			namespace *corev1.Namespace
+			denyList  *corev1.ConfigMap
+			labelsCR  *labelsv1alpha1.Namespacelabel
+		)
+
+		BeforeEach(func() {
+			DeferCleanup(os.Setenv, denylist.ConfigMapEnv, os.Getenv(denylist.ConfigMapEnv))
+			Expect(os.Setenv(denylist.ConfigMapEnv, "operator-system/deny-namespaces")).To(Succeed())
+
+			namespace = newNamespace(nil)
+			denyList = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "deny-namespaces", Namespace: "operator-system"},
+				Data:       map[string]string{denylist.NamespacesKey: "kube-system"},
+			}
+			labelsCR = newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+		})
+
+		It("should stop managing a namespace once it is added to the deny ConfigMap", func() {
+			reconciler, _ := newTestReconciler(namespace, denyList, labelsCR)
+			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: NamespaceLabelCR, Namespace: NamespaceName}}
+
+			By("Reconciling while the namespace is not denied")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+
+			By("Adding the namespace to the deny ConfigMap")
+			denyList.Data[denylist.NamespacesKey] = "kube-system,\n" + NamespaceName
+			Expect(reconciler.Update(ctx, denyList)).To(Succeed())
+			Expect(reconciler.enqueueRequestsFromConfigMap(ctx, denyList)).To(ConsistOf(request))
+
+			By("Changing the managed label out-of-band and reconciling")
+			namespace.Labels["team"] = "changed"
+			Expect(reconciler.Update(ctx, namespace)).To(Succeed())
+			mustReconcile(reconciler, request)
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "changed"))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionNamespaceDenied))).To(BeTrue())
+
+			By("Deleting the Namespacelabel CR and verifying the labels are left in place")
+			Expect(reconciler.Delete(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "changed"))
+		})
+	})
+	Context("Applied label history", func() {
+		It("should record each distinct set of applied labels and keep only the most recent ones", func() {
+			DeferCleanup(os.Setenv, StatusHistoryLimitEnv, os.Getenv(StatusHistoryLimitEnv))
+			Expect(os.Setenv(StatusHistoryLimitEnv, "2")).To(Succeed())
+
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"version": "1"}})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			fakeClock := clocktesting.NewFakePassiveClock(time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC))
+			reconciler.Clock = fakeClock
+			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: NamespaceLabelCR, Namespace: NamespaceName}}
+
+			applyVersion := func(version string) {
+				Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+				labelsCR.Spec.Labels = map[string]string{"version": version}
+				Expect(reconciler.Update(ctx, labelsCR)).To(Succeed())
+				fakeClock.SetTime(fakeClock.Now().Add(time.Minute))
+				mustReconcile(reconciler, request)
+				Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			}
+
+			By("Applying the first set of labels")
+			mustReconcile(reconciler, request)
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.History).To(HaveLen(1))
+
+			By("Reconciling without changes and verifying the history does not grow")
+			applyVersion("1")
+			Expect(labelsCR.Status.History).To(HaveLen(1))
+
+			By("Applying two more sets and verifying the oldest is dropped")
+			applyVersion("2")
+			Expect(labelsCR.Status.History).To(HaveLen(2))
+			applyVersion("3")
+			Expect(labelsCR.Status.History).To(HaveLen(2))
+			Expect(labelsCR.Status.History[0].Labels).To(Equal(map[string]string{"version": "2"}))
+			Expect(labelsCR.Status.History[1].Labels).To(Equal(map[string]string{"version": "3"}))
+			Expect(labelsCR.Status.History[1].Time.Time).To(BeTemporally("==", fakeClock.Now()))
+		})
+	})
+	Context("Bounded cleanup concurrency", func() {
+		It("should never clean up more Namespacelabels at once than there are cleanup workers", func() {
+			const deleted = 6
+			now := metav1.Now()
+			var objs []client.Object
+			var requests []ctrl.Request
+			for i := 0; i < deleted; i++ {
+				name := fmt.Sprintf("cleanup-%d", i)
+				objs = append(objs,
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"team": "platform"}}},
+					&labelsv1alpha1.Namespacelabel{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              NamespaceLabelCR,
+							Namespace:         name,
+							DeletionTimestamp: &now,
+							Finalizers:        []string{"namespacelabels.finalizers.dana.io"},
+						},
+						Spec:   labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}},
+						Status: labelsv1alpha1.NamespacelabelStatus{AppliedLabels: map[string]string{"team": "platform"}},
+					},
+				)
+				requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: NamespaceLabelCR, Namespace: name}})
+			}
+
+			var inFlight, maxInFlight atomic.Int32
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok {
+						current := inFlight.Add(1)
+						defer inFlight.Add(-1)
+						for {
+							seen := maxInFlight.Load()
+							if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+								break
+							}
+						}
+						time.Sleep(20 * time.Millisecond)
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}, objs...)
+			reconciler.CleanupWorkers = 2
+			reconciler.CleanupBackoff = 5 * time.Millisecond
+
+			By("Reconciling every deleted Namespacelabel at once, retrying those told to back off")
+			var requeued atomic.Int32
+			var wg sync.WaitGroup
+			for _, request := range requests {
+				wg.Add(1)
+				go func(request ctrl.Request) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					for {
+						result, err := reconciler.Reconcile(ctx, request)
+						Expect(err).NotTo(HaveOccurred())
+						if result.RequeueAfter == 0 {
+							return
+						}
+						Expect(result.RequeueAfter).To(Equal(reconciler.CleanupBackoff))
+						requeued.Add(1)
+						time.Sleep(result.RequeueAfter)
+					}
+				}(request)
+			}
+			wg.Wait()
+
+			By("Verifying the bound held and every namespace was cleaned up")
+			Expect(maxInFlight.Load()).To(BeNumerically("<=", 2))
+			Expect(requeued.Load()).To(BeNumerically(">", 0))
+			for i := 0; i < deleted; i++ {
+				namespace := &corev1.Namespace{}
+				Expect(reconciler.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("cleanup-%d", i)}, namespace)).To(Succeed())
+				Expect(namespace.Labels).NotTo(HaveKey("team"))
+			}
+		})
+	})
+	Context("Reconcile error condition", func() {
+		It("should report the last reconcile error and clear it once a reconcile succeeds", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+			failNamespaceWrites := true
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok && failNamespaceWrites {
+						return errors.NewServiceUnavailable("namespace writes unavailable")
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}, namespace, labelsCR)
+			request := requestFor(labelsCR)
+
+			By("Reconciling while namespace writes fail")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).To(HaveOccurred())
+
+			By("Verifying the error is reported on the CR")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionReconcileError))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonReconcileFailed)))
+			Expect(condition.Message).To(ContainSubstring("namespace writes unavailable"))
+			Expect(labelsCR.Status.ActiveConditions).To(ContainElement(string(labelsv1alpha1.ConditionReconcileError)))
+
+			By("Reconciling once namespace writes recover")
+			failNamespaceWrites = false
+			mustReconcile(reconciler, request)
+
+			By("Verifying the error was cleared")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			condition = meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionReconcileError))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonReconcileSucceeded)))
+			Expect(labelsCR.Status.ActiveConditions).NotTo(ContainElement(string(labelsv1alpha1.ConditionReconcileError)))
+		})
+	})
+	Context("Namespace access check", func() {
+		It("should skip a namespace the operator may not update and apply once access is granted", func() {
+			DeferCleanup(os.Setenv, CheckNamespaceAccessEnv, os.Getenv(CheckNamespaceAccessEnv))
+			Expect(os.Setenv(CheckNamespaceAccessEnv, "true")).To(Succeed())
+
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+			allowed := false
+			var reviewed []string
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+						attributes := review.Spec.ResourceAttributes
+						reviewed = append(reviewed, fmt.Sprintf("%s %s/%s", attributes.Verb, attributes.Resource, attributes.Name))
+						review.Status.Allowed = allowed
+						return nil
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			}, namespace, labelsCR)
+			request := requestFor(labelsCR)
+
+			By("Reconciling while the operator may not update the namespace")
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(noPermissionRequeueAfter))
+			Expect(reviewed).To(Equal([]string{"patch namespaces/" + NamespaceName}))
+
+			By("Verifying nothing was applied and the missing permission is reported")
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Labels).NotTo(HaveKey("team"))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionNoPermission))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonNamespaceUpdateForbidden)))
+
+			By("Reconciling once access is granted")
+			allowed = true
+			result, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionNoPermission))).To(BeNil())
+		})
+	})
+	Context("Yielded label keys", func() {
+		It("should keep an out-of-band change to a yielded key and revert other keys", func() {
+			DeferCleanup(os.Setenv, labels.YieldKeysEnv, os.Getenv(labels.YieldKeysEnv))
+			Expect(os.Setenv(labels.YieldKeysEnv, "policy.example.com/tier")).To(Succeed())
+
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"policy.example.com/tier": "gold", "team": "platform"},
+			})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+			request := requestFor(labelsCR)
+
+			By("Applying the labels")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Changing both labels out-of-band, as a policy engine would")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			namespace.Labels["policy.example.com/tier"] = "silver"
+			namespace.Labels["team"] = "payments"
+			Expect(reconciler.Update(ctx, namespace)).To(Succeed())
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+
+			By("Reconciling and verifying only the yielded key keeps its new value")
+			mustReconcile(reconciler, request)
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("policy.example.com/tier", "silver"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(HaveKeyWithValue("policy.example.com/tier", "silver"))
+			events := drainEvents(recorder)
+			Expect(events).To(ContainElement(ContainSubstring("YieldedToExternalChange")))
+
+			By("Reconciling again and verifying the yield is not reported twice")
+			mustReconcile(reconciler, request)
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("policy.example.com/tier", "silver"))
+			events = nil
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			Expect(events).NotTo(ContainElement(ContainSubstring("YieldedToExternalChange")))
+		})
+	})
+	Context("Shadow mode", func() {
+		It("should record plans without ever writing a namespace, including on deletion", func() {
+			DeferCleanup(os.Setenv, ShadowModeEnv, os.Getenv(ShadowModeEnv))
+			Expect(os.Setenv(ShadowModeEnv, "true")).To(Succeed())
+
+			namespace := newNamespace(map[string]string{"team": "platform"})
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform", "env": "prod"}})
+			var namespaceWrites atomic.Int32
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok {
+						namespaceWrites.Add(1)
+					}
+					return c.Update(ctx, obj, opts...)
+				},
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok {
+						namespaceWrites.Add(1)
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}, namespace, labelsCR)
+			request := requestFor(labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the plan was recorded and the namespace left alone")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Annotations[labels.DryRunPlanAnnotation]).To(MatchJSON(`{"apply":{"env":"prod"},"duplicates":{"team":"platform"}}`))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(Equal(map[string]string{"team": "platform"}))
+
+			By("Deleting the Namespacelabel CR and reconciling the deletion")
+			Expect(reconciler.Delete(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+			Expect(errors.IsNotFound(reconciler.Get(ctx, request.NamespacedName, labelsCR))).To(BeTrue())
+
+			By("Verifying no namespace write ever happened")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(namespaceWrites.Load()).To(BeZero())
+		})
+	})
+	Context("Printing plans to stdout", func() {
+		It("should print one parseable JSON line describing every desired label", func() {
+			DeferCleanup(os.Setenv, PrintPlansEnv, os.Getenv(PrintPlansEnv))
+			Expect(os.Setenv(PrintPlansEnv, "true")).To(Succeed())
+
+			namespace := newNamespace(map[string]string{"owner": "someone"})
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform", "owner": "platform", "protected-label": "value"},
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Capturing stdout while reconciling")
+			reader, writer, err := os.Pipe()
+			Expect(err).NotTo(HaveOccurred())
+			stdout := os.Stdout
+			os.Stdout = writer
+			DeferCleanup(func() { os.Stdout = stdout })
+
+			_, err = reconciler.Reconcile(ctx, requestFor(labelsCR))
+			os.Stdout = stdout
+			Expect(writer.Close()).To(Succeed())
+			Expect(err).NotTo(HaveOccurred())
+			output, err := io.ReadAll(reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying a single valid JSON line was printed")
+			lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+			Expect(lines).To(HaveLen(1))
+			var printed printedPlan
+			Expect(json.Unmarshal([]byte(lines[0]), &printed)).To(Succeed())
+			Expect(printed.Namespacelabel).To(Equal(NamespaceName + "/" + NamespaceLabelCR))
+			Expect(printed.Namespace).To(Equal(NamespaceName))
+			Expect(printed.DryRun).To(BeFalse())
+			Expect(printed.Actions).To(Equal([]planAction{
+				{Key: "owner", Action: "duplicate", Value: "platform"},
+				{Key: "protected-label", Action: "skip", Value: "value", Reason: labelsv1alpha1.SkipReasonProtected},
+				{Key: "team", Action: "apply", Value: "platform"},
+			}))
+		})
+	})
+	Context("Aggregated managed labels", func() {
+		It("should record the union of the labels every Namespacelabel applied to the namespace, with their owners", func() {
+			DeferCleanup(os.Setenv, AggregateManagedLabelsEnv, os.Getenv(AggregateManagedLabelsEnv))
+			Expect(os.Setenv(AggregateManagedLabelsEnv, "true")).To(Succeed())
+
+			shared := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shared"}}
+			platformCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "platform"},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{NamespaceName: "shared", Labels: map[string]string{"team": "platform"}},
+			}
+			billingCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "billing"},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{NamespaceName: "shared", Labels: map[string]string{"cost-center": "cc-1234"}},
+			}
+			reconciler, _ := newTestReconciler(shared, platformCR, billingCR)
+
+			By("Reconciling both Namespacelabels")
+			for _, labelsCR := range []*labelsv1alpha1.Namespacelabel{platformCR, billingCR} {
+				mustReconcile(reconciler, requestFor(labelsCR))
+			}
+
+			By("Verifying the namespace lists both contributions")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "shared"}, shared)).To(Succeed())
+			Expect(shared.Annotations[labels.ManagedLabelsAnnotation]).To(MatchJSON(`{
+				"cost-center": {"value": "cc-1234", "owner": "billing/labels"},
+				"team": {"value": "platform", "owner": "platform/labels"}
+			}`))
+
+			By("Deleting one Namespacelabel and verifying only the other's labels remain listed")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(billingCR), billingCR)).To(Succeed())
+			Expect(reconciler.Delete(ctx, billingCR)).To(Succeed())
+			mustReconcile(reconciler, requestFor(billingCR))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "shared"}, shared)).To(Succeed())
+			Expect(shared.Labels).NotTo(HaveKey("cost-center"))
+			Expect(shared.Annotations[labels.ManagedLabelsAnnotation]).To(MatchJSON(`{
+				"team": {"value": "platform", "owner": "platform/labels"}
+			}`))
+		})
+	})
+	Context("Required resource", func() {
+		var (
+			namespace *corev1.Namespace
+			labelsCR  *labelsv1alpha1.Namespacelabel
+		)
+
+		BeforeEach(func() {
+			namespace = newNamespace(nil)
+			labelsCR = newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:   map[string]string{"team": "platform"},
+				Requires: &labelsv1alpha1.ResourceReference{APIVersion: "v1", Kind: "ConfigMap", Name: "team-config"},
+			})
+		})
+
+		It("should apply labels when the required resource exists", func() {
+			required := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "team-config", Namespace: NamespaceName}}
+			reconciler, _ := newTestReconciler(namespace, labelsCR, required)
+			request := requestFor(labelsCR)
+
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionDependencyMissing))).To(BeTrue())
+		})
+
+		It("should hold back labels and requeue while the required resource is missing", func() {
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			request := requestFor(labelsCR)
+
+			By("Reconciling before the required resource exists")
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(dependencyRequeueAfter))
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("team"))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionDependencyMissing))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("ConfigMap team-config"))
+
+			By("Creating the required resource and reconciling again")
+			Expect(reconciler.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "team-config", Namespace: NamespaceName}})).To(Succeed())
+			result, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+		})
+	})
+	Context("Namespace write throttle", func() {
+		It("should space namespace writes according to the configured rate", func() {
+			var objs []client.Object
+			var requests []ctrl.Request
+			for i := 0; i < 3; i++ {
+				name := fmt.Sprintf("throttled-%d", i)
+				labelsCR := &labelsv1alpha1.Namespacelabel{
+					ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: name},
+					Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}},
+				}
+				objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, labelsCR)
+				requests = append(requests, requestFor(labelsCR))
+			}
+
+			var writes []time.Time
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok {
+						writes = append(writes, time.Now())
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}, objs...)
+			reconciler.Client = throttle.NewNamespaceWriteLimitedClient(reconciler.Client, 10, 1)
+
+			By("Reconciling a Namespacelabel per namespace")
+			for _, request := range requests {
+				mustReconcile(reconciler, request)
+			}
+
+			By("Verifying each namespace write waited for its turn")
+			Expect(writes).To(HaveLen(3))
+			for i := 1; i < len(writes); i++ {
+				Expect(writes[i].Sub(writes[i-1])).To(BeNumerically(">=", 80*time.Millisecond))
+			}
+		})
+	})
+
+	Context("Observe-only", func() {
+		It("should report the namespace's current labels without applying anything", func() {
+			namespace := newNamespace(map[string]string{"app": "web"})
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:      map[string]string{"team": "platform"},
+				ObserveOnly: true,
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the observe-only Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the namespace was left untouched")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(Equal(map[string]string{"app": "web"}))
+
+			By("Verifying the status reports the namespace's labels")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Finalizers).To(BeEmpty())
+			Expect(labelsCR.Status.AppliedLabels).To(BeEmpty())
+			Expect(labelsCR.Status.ObservedLabels).To(Equal(map[string]string{"app": "web"}))
+			Expect(meta.IsStatusConditionTrue(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionObserving))).To(BeTrue())
+
+			By("Changing the namespace's labels and reconciling again")
+			namespace.Labels["env"] = "prod"
+			Expect(reconciler.Update(ctx, namespace)).To(Succeed())
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the status follows the namespace")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.ObservedLabels).To(Equal(map[string]string{"app": "web", "env": "prod"}))
+		})
+	})
+
+	Context("Deprecated label keys", func() {
+		It("should apply a deprecated key and warn about it", func() {
+			DeferCleanup(os.Setenv, labels.DeprecatedKeysEnv, os.Getenv(labels.DeprecatedKeysEnv))
+			Expect(os.Setenv(labels.DeprecatedKeysEnv, "owner, cost-center")).To(Succeed())
+
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"owner": "platform", "team": "platform"},
+			})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			By("Verifying the deprecated key was still applied")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("owner", "platform"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+
+			By("Verifying a DeprecatedLabel warning was recorded for the deprecated key only")
+			events := drainEvents(recorder)
+			Expect(events).To(ContainElement(And(HavePrefix(corev1.EventTypeWarning+" DeprecatedLabel"), ContainSubstring("owner"))))
+			Expect(events).NotTo(ContainElement(And(ContainSubstring("DeprecatedLabel"), ContainSubstring("team"))))
+
+			By("Verifying the DeprecatedLabels condition lists the key")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionDeprecatedLabels))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("owner"))
+		})
+	})
+
+	Context("Bounded reconcile retries", func() {
+		It("should stop retrying after consecutive failures and start over on a spec change", func() {
+			DeferCleanup(os.Setenv, MaxReconcileFailuresEnv, os.Getenv(MaxReconcileFailuresEnv))
+			Expect(os.Setenv(MaxReconcileFailuresEnv, "3")).To(Succeed())
+
+			namespace := newNamespace(nil)
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName, Generation: 1},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}},
+			}
+			namespaceWrites := 0
+			reconciler, recorder := newInterceptedTestReconciler(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok {
+						namespaceWrites++
+						return errors.NewServiceUnavailable("namespace writes unavailable")
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}, namespace, labelsCR)
+			request := requestFor(labelsCR)
+
+			By("Failing below the bound and being retried")
+			for i := 0; i < 2; i++ {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).To(HaveOccurred())
+			}
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.ConsecutiveFailures).To(BeEquivalentTo(2))
+			Expect(labelsCR.Status.Phase).To(BeEmpty())
+
+			By("Reaching the bound and entering the Failed phase")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.Phase).To(Equal(labelsv1alpha1.PhaseFailed))
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionReconcileError))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonRetriesExhausted)))
+			Expect(condition.Message).To(ContainSubstring("namespace writes unavailable"))
+			events := drainEvents(recorder)
+			Expect(events).To(ContainElement(ContainSubstring("RetriesExhausted")))
+
+			By("Verifying a failed Namespacelabel is not reconciled again")
+			writes := namespaceWrites
+			mustReconcile(reconciler, request)
+			Expect(namespaceWrites).To(Equal(writes))
+
+			By("Changing the spec and verifying the count starts over")
+			labelsCR.Spec.Labels["env"] = "prod"
+			labelsCR.Generation = 2
+			Expect(reconciler.Update(ctx, labelsCR)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).To(HaveOccurred())
+			Expect(namespaceWrites).To(BeNumerically(">", writes))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.Phase).To(BeEmpty())
+			Expect(labelsCR.Status.ConsecutiveFailures).To(BeEquivalentTo(1))
+			Expect(labelsCR.Status.FailedGeneration).To(BeEquivalentTo(2))
+		})
+	})
+
+	Context("Managed namespace summary", func() {
+		It("should keep the summary up to date as Namespacelabels come and go", func() {
+			payments := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}}
+			search := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "search"}}
+			paymentsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: "payments"},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "payments", "env": "prod"}},
+			}
+			searchCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: "search"},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "search"}},
+			}
+			reconciler, _ := newTestReconciler(payments, search, paymentsCR, searchCR)
+			summary := &labelsv1alpha1.NamespacelabelSummary{}
+
+			By("Reconciling the first Namespacelabel and verifying the summary is created")
+			_, err := reconciler.Reconcile(ctx, requestFor(paymentsCR))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: labelsv1alpha1.SummaryName}, summary)).To(Succeed())
+			Expect(summary.Status.Namespaces).To(Equal([]labelsv1alpha1.ManagedNamespace{
+				{Name: "payments", AppliedLabels: 2, Namespacelabels: 1},
+			}))
+			Expect(summary.Status.TotalAppliedLabels).To(BeEquivalentTo(2))
+
+			By("Reconciling the second Namespacelabel and verifying it is added")
+			mustReconcile(reconciler, requestFor(searchCR))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: labelsv1alpha1.SummaryName}, summary)).To(Succeed())
+			Expect(summary.Status.Namespaces).To(Equal([]labelsv1alpha1.ManagedNamespace{
+				{Name: "payments", AppliedLabels: 2, Namespacelabels: 1},
+				{Name: "search", AppliedLabels: 1, Namespacelabels: 1},
+			}))
+			Expect(summary.Status.TotalAppliedLabels).To(BeEquivalentTo(3))
+
+			By("Deleting the first Namespacelabel and verifying it is dropped")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(paymentsCR), paymentsCR)).To(Succeed())
+			Expect(reconciler.Delete(ctx, paymentsCR)).To(Succeed())
+			mustReconcile(reconciler, requestFor(paymentsCR))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: labelsv1alpha1.SummaryName}, summary)).To(Succeed())
+			Expect(summary.Status.Namespaces).To(Equal([]labelsv1alpha1.ManagedNamespace{
+				{Name: "search", AppliedLabels: 1, Namespacelabels: 1},
+			}))
+			Expect(summary.Status.TotalAppliedLabels).To(BeEquivalentTo(1))
+		})
+	})
+
+	Context("Template variables", func() {
+		It("should render values from the variables ConfigMap and follow its changes", func() {
+			DeferCleanup(os.Setenv, vars.ConfigMapEnv, os.Getenv(vars.ConfigMapEnv))
+			Expect(os.Setenv(vars.ConfigMapEnv, "operator-system/template-vars")).To(Succeed())
+
+			namespace := newNamespace(nil)
+			varsConfigMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "template-vars", Namespace: "operator-system"},
+				Data:       map[string]string{"region": "eu-west-1"},
+			}
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:           map[string]string{"region": "{{ .Vars.region }}", "team": "platform"},
+				EnableTemplating: true,
+			})
+			plainCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"env": "prod"}},
+			}
+			reconciler, _ := newTestReconciler(namespace, varsConfigMap, labelsCR, plainCR)
+			request := requestFor(labelsCR)
+
+			By("Reconciling and verifying the template was rendered")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("region", "eu-west-1"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+
+			By("Verifying a change to the variables ConfigMap requeues only templated Namespacelabels")
+			varsConfigMap.Data["region"] = "us-east-1"
+			Expect(reconciler.Update(ctx, varsConfigMap)).To(Succeed())
+			Expect(reconciler.enqueueRequestsFromConfigMap(ctx, varsConfigMap)).To(ConsistOf(request))
+
+			By("Reconciling and verifying the new value was applied")
+			mustReconcile(reconciler, request)
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("region", "us-east-1"))
+		})
+
+		It("should skip a label whose template references a missing variable and apply the rest", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:           map[string]string{"region": "{{ .Vars.region }}", "team": "platform"},
+				EnableTemplating: true,
+			})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+			request := requestFor(labelsCR)
+
+			By("Reconciling without a variables ConfigMap")
+			mustReconcile(reconciler, request)
+
+			By("Verifying only the rendered label was applied")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("region"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+
+			By("Verifying the unrendered label was recorded and reported")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.SkipReasons).To(Equal(map[string]labelsv1alpha1.SkipReason{
+				"region": labelsv1alpha1.SkipReasonRenderFailed,
+			}))
+			events := drainEvents(recorder)
+			Expect(events).To(ContainElement(And(ContainSubstring("RenderFailed"), ContainSubstring("failed to render label region"))))
+		})
+	})
+
+	Context("Redacted status values", func() {
+		It("should keep label values out of the status and events", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:             map[string]string{"cost-center": "cc-secret-1234", "protected-label": "secret-5678"},
+				VerboseEvents:      true,
+				RedactStatusValues: true,
+			})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the real value was applied to the namespace")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("cost-center", "cc-secret-1234"))
+
+			By("Verifying the status records keys with redacted values")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(Equal(map[string]string{"cost-center": labels.RedactedValue}))
+			Expect(labelsCR.Status.SkippedLabels).To(Equal(map[string]string{"protected-label": labels.RedactedValue}))
+			Expect(labelsCR.Status.History).To(HaveLen(1))
+			Expect(labelsCR.Status.History[0].Labels).To(Equal(map[string]string{"cost-center": labels.RedactedValue}))
+
+			By("Verifying no event names a value")
+			events := drainEvents(recorder)
+			Expect(events).To(ContainElement(ContainSubstring("cost-center=" + labels.RedactedValue)))
+			Expect(events).To(ContainElement(ContainSubstring("protected-label=" + labels.RedactedValue)))
+			Expect(events).NotTo(ContainElement(Or(ContainSubstring("cc-secret-1234"), ContainSubstring("secret-5678"))))
+
+			By("Reconciling again and verifying the redacted status is not reported as drift")
+			mustReconcile(reconciler, requestFor(labelsCR))
+			events = nil
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			Expect(events).NotTo(ContainElement(Or(ContainSubstring("DriftDetected"), ContainSubstring("ChecksumMismatch"))))
+		})
+	})
+
+	Context("Spec hash label", func() {
+		It("should track the applied labels in the spec hash label and remove it on cleanup", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels:         map[string]string{"team": "platform"},
+				RecordSpecHash: true,
+			})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			request := requestFor(labelsCR)
+			hashKey := hashKeyKey(NamespaceName, NamespaceLabelCR)
+
+			By("Reconciling and verifying the hash label was written")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			firstHash := namespace.Labels[hashKey]
+			Expect(firstHash).To(Equal(labels.SpecHash(map[string]string{"team": "platform"})))
+			Expect(firstHash).To(HaveLen(10))
+
+			By("Changing the spec and verifying the hash follows")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			labelsCR.Spec.Labels["env"] = "prod"
+			Expect(reconciler.Update(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels[hashKey]).NotTo(Equal(firstHash))
+			Expect(namespace.Labels[hashKey]).To(Equal(labels.SpecHash(map[string]string{"team": "platform", "env": "prod"})))
+
+			By("Deleting the Namespacelabel and verifying the hash label was removed")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(reconciler.Delete(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey(hashKey))
+			Expect(namespace.Labels).NotTo(HaveKey("team"))
+		})
+
+		It("should keep a separate hash label per Namespacelabel targeting the namespace", func() {
+			namespace := newNamespace(nil)
+			first := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}, RecordSpecHash: true})
+			second := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"env": "prod"}, RecordSpecHash: true},
+			}
+			namespaceWrites := 0
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok {
+						namespaceWrites++
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}, namespace, first, second)
+
+			By("Reconciling both Namespacelabels")
+			for _, labelsCR := range []*labelsv1alpha1.Namespacelabel{first, second} {
+				mustReconcile(reconciler, requestFor(labelsCR))
+			}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue(labels.SpecHashLabelKey(NamespaceName, NamespaceLabelCR), labels.SpecHash(map[string]string{"team": "platform"})))
+			Expect(namespace.Labels).To(HaveKeyWithValue(labels.SpecHashLabelKey(NamespaceName, "other"), labels.SpecHash(map[string]string{"env": "prod"})))
+
+			By("Reconciling both again and verifying neither writes the namespace")
+			namespaceWrites = 0
+			for _, labelsCR := range []*labelsv1alpha1.Namespacelabel{first, second} {
+				mustReconcile(reconciler, requestFor(labelsCR))
+			}
+			Expect(namespaceWrites).To(BeZero())
+
+			By("Deleting one Namespacelabel and verifying only its hash label was removed")
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(second), second)).To(Succeed())
+			Expect(reconciler.Delete(ctx, second)).To(Succeed())
+			mustReconcile(reconciler, requestFor(second))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey(labels.SpecHashLabelKey(NamespaceName, "other")))
+			Expect(namespace.Labels).To(HaveKey(labels.SpecHashLabelKey(NamespaceName, NamespaceLabelCR)))
+		})
+	})
+
+	Context("Simultaneous Namespacelabels for one namespace", func() {
+		It("should apply the labels of every Namespacelabel reconciled at the same time", func() {
+			shared := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shared"}}
+			objs := []client.Object{shared}
+			var labelsCRs []*labelsv1alpha1.Namespacelabel
+			for i := 0; i < 5; i++ {
+				labelsCR := &labelsv1alpha1.Namespacelabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: fmt.Sprintf("team-%d", i)},
+					Spec: labelsv1alpha1.NamespacelabelSpec{
+						NamespaceName: "shared",
+						Labels:        map[string]string{fmt.Sprintf("team-%d", i): "member"},
+					},
+				}
+				labelsCRs = append(labelsCRs, labelsCR)
+				objs = append(objs, labelsCR)
+			}
+			reconciler, _ := newTestReconciler(objs...)
+
+			By("Reconciling every Namespacelabel concurrently")
+			var wg sync.WaitGroup
+			errs := make(chan error, len(labelsCRs))
+			for _, labelsCR := range labelsCRs {
+				wg.Add(1)
+				go func(labelsCR *labelsv1alpha1.Namespacelabel) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					_, err := reconciler.Reconcile(ctx, requestFor(labelsCR))
+					errs <- err
+				}(labelsCR)
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			By("Verifying every label landed on the namespace")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "shared"}, shared)).To(Succeed())
+			for i := range labelsCRs {
+				Expect(shared.Labels).To(HaveKeyWithValue(fmt.Sprintf("team-%d", i), "member"))
+			}
+		})
+	})
+
+	Context("Namespacelabel without a namespace", func() {
+		It("should report the missing namespace instead of reading a namespace with an empty name", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}},
+			}
+			var namespaceGets atomic.Int32
+			reconciler, recorder := newInterceptedTestReconciler(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok {
+						namespaceGets.Add(1)
+					}
+					return c.Get(ctx, key, obj, opts...)
+				},
+			}, labelsCR)
+
+			mustReconcile(reconciler, requestFor(labelsCR))
+			Expect(namespaceGets.Load()).To(BeZero())
+
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Finalizers).To(BeEmpty())
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionLabelsApplied))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonMissingNamespace)))
+			Expect(recorder.Events).To(Receive(ContainSubstring("Warning MissingNamespace")))
+		})
+	})
+
+	Context("Force-reconcile annotation", func() {
+		It("should re-emit the current state once per new nonce", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform", "protected-label": "value"}})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+			request := requestFor(labelsCR)
+			reconcileEvents := func() []string {
+				mustReconcile(reconciler, request)
+				recorded := drainEvents(recorder)
+				return recorded
+			}
+			setNonce := func(nonce string) {
+				Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+				labelsCR.Annotations = map[string]string{labels.ForceReconcileAnnotation: nonce}
+				Expect(reconciler.Update(ctx, labelsCR)).To(Succeed())
+			}
+
+			By("Reconciling without the annotation")
+			Expect(reconcileEvents()).NotTo(ContainElement(ContainSubstring("ForcedReconcile")))
+			Expect(reconcileEvents()).NotTo(ContainElement(ContainSubstring("ForcedReconcile")))
+
+			By("Setting a nonce and verifying the state is re-emitted")
+			setNonce("1")
+			recorded := reconcileEvents()
+			Expect(recorded).To(ContainElement(And(ContainSubstring("Normal ForcedReconcile"), ContainSubstring("1 applied, 1 skipped, 0 duplicates"))))
+			Expect(recorded).To(ContainElement(And(ContainSubstring("Warning LabelsSkipped"), ContainSubstring("protected-label"))))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.ForceReconcileNonce).To(Equal("1"))
+
+			By("Reconciling again with the same nonce")
+			Expect(reconcileEvents()).NotTo(ContainElement(ContainSubstring("ForcedReconcile")))
+
+			By("Changing the nonce and verifying the state is re-emitted again")
+			setNonce("2")
+			Expect(reconcileEvents()).To(ContainElement(ContainSubstring("Normal ForcedReconcile")))
+		})
+	})
+
+	Context("Condition message templates", func() {
+		var (
+			namespace *corev1.Namespace
+			labelsCR  *labelsv1alpha1.Namespacelabel
+		)
+
+		BeforeEach(func() {
+			DeferCleanup(os.Setenv, conditions.MessageTemplatesEnv, os.Getenv(conditions.MessageTemplatesEnv))
+			namespace = newNamespace(nil)
+			labelsCR = newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+		})
+
+		reconcileConditions := func() []metav1.Condition {
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			mustReconcile(reconciler, requestFor(labelsCR))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			return labelsCR.Status.Conditions
+		}
+
+		It("should render the configured templates, preferring the type and reason entry", func() {
+			Expect(os.Setenv(conditions.MessageTemplatesEnv, `{
+				"LabelsApplied": "{{ .Message }} See https://docs.example.com/{{ .Namespace }}/{{ .Name }}",
+				"DuplicateLabels/DuplicateLabelsHandled": "Doublons: {{ .Status }}"
+			}`)).To(Succeed())
+
+			reconciled := reconcileConditions()
+			applied := meta.FindStatusCondition(reconciled, string(labelsv1alpha1.ConditionLabelsApplied))
+			Expect(applied).NotTo(BeNil())
+			Expect(applied.Message).To(Equal("All labels were applied with no skips or duplicates. See https://docs.example.com/" + NamespaceName + "/" + NamespaceLabelCR))
+			duplicates := meta.FindStatusCondition(reconciled, string(labelsv1alpha1.ConditionDuplicateLabels))
+			Expect(duplicates).NotTo(BeNil())
+			Expect(duplicates.Message).To(Equal("Doublons: False"))
+		})
+
+		It("should keep the default messages when the templates are invalid", func() {
+			Expect(os.Setenv(conditions.MessageTemplatesEnv, `{"LabelsApplied": "{{ .Message"}`)).To(Succeed())
+
+			applied := meta.FindStatusCondition(reconcileConditions(), string(labelsv1alpha1.ConditionLabelsApplied))
+			Expect(applied).NotTo(BeNil())
+			Expect(applied.Message).To(Equal("All labels were applied with no skips or duplicates."))
+		})
+	})
+
+	Context("Unchanged status", func() {
+		It("should not write status when a reconcile computes the same status", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+			var statusUpdates atomic.Int32
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					statusUpdates.Add(1)
+					return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+				},
+			}, namespace, labelsCR)
+			request := requestFor(labelsCR)
+
+			By("Reconciling once to record the status")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusUpdates.Load()).To(BeNumerically(">", 0))
+
+			By("Reconciling again without any change")
+			statusUpdates.Store(0)
+			mustReconcile(reconciler, request)
+			Expect(statusUpdates.Load()).To(BeZero())
+
+			By("Changing the spec and verifying status is written again")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			labelsCR.Spec.Labels["env"] = "prod"
+			Expect(reconciler.Update(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+			Expect(statusUpdates.Load()).To(BeNumerically(">", 0))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(HaveKeyWithValue("env", "prod"))
+		})
+	})
+
+	Context("Clean apply", func() {
+		It("should report a clean apply only when labels were applied with no skips or duplicates", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+			request := requestFor(labelsCR)
+
+			By("Applying labels cleanly")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Normal CleanApply All 1 labels were applied")))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionLabelsApplied))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonCleanApply)))
+
+			By("Reconciling again without changes")
+			mustReconcile(reconciler, request)
+			Expect(drainEvents(recorder)).NotTo(ContainElement(ContainSubstring("CleanApply")))
+
+			By("Adding a protected label that is skipped")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			labelsCR.Spec.Labels["env"] = "prod"
+			labelsCR.Spec.Labels["protected-label"] = "value"
+			Expect(reconciler.Update(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+			Expect(drainEvents(recorder)).NotTo(ContainElement(ContainSubstring("CleanApply")))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			condition = meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionLabelsApplied))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonLabelsReconciled)))
+		})
+	})
+
+	Context("Case-insensitive values", func() {
+		var (
+			reconciler *NamespacelabelReconciler
+			recorder   *record.FakeRecorder
+			namespace  *corev1.Namespace
+			request    ctrl.Request
+		)
+
+		BeforeEach(func() {
+			DeferCleanup(os.Setenv, labels.CaseInsensitiveValuesEnv, os.Getenv(labels.CaseInsensitiveValuesEnv))
+			namespace = newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"env": "prod"}})
+			reconciler, recorder = newTestReconciler(namespace, labelsCR)
+			request = requestFor(labelsCR)
+
+			By("Applying the label")
+			mustReconcile(reconciler, request)
+
+			By("Changing the case of the value on the namespace out-of-band")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			namespace.Labels["env"] = "Prod"
+			Expect(reconciler.Update(ctx, namespace)).To(Succeed())
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+		})
+
+		reconcileEvents := func() []string {
+			mustReconcile(reconciler, request)
+			recorded := drainEvents(recorder)
+			return recorded
+		}
+
+		It("should keep a value that only differs in case when values are compared case-insensitively", func() {
+			Expect(os.Setenv(labels.CaseInsensitiveValuesEnv, "true")).To(Succeed())
+
+			recorded := reconcileEvents()
+			Expect(recorded).NotTo(ContainElement(ContainSubstring("DriftDetected")))
+			Expect(recorded).NotTo(ContainElement(ContainSubstring("ChecksumMismatch")))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("env", "Prod"))
+
+			labelsCR := &labelsv1alpha1.Namespacelabel{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(Equal(map[string]string{"env": "Prod"}))
+		})
+
+		It("should restore the desired value when values are compared case-sensitively", func() {
+			Expect(os.Unsetenv(labels.CaseInsensitiveValuesEnv)).To(Succeed())
+
+			Expect(reconcileEvents()).To(ContainElement(ContainSubstring("DriftDetected")))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("env", "prod"))
+		})
+
+		DescribeTable("should treat a value someone else set that only differs in case",
+			func(caseInsensitive bool, policy labelsv1alpha1.OverwritePolicy, wantEvent string, wantValue string) {
+				if caseInsensitive {
+					Expect(os.Setenv(labels.CaseInsensitiveValuesEnv, "true")).To(Succeed())
+				} else {
+					Expect(os.Unsetenv(labels.CaseInsensitiveValuesEnv)).To(Succeed())
+				}
+				foreign := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tiered", Labels: map[string]string{"tier": "Prod"}}}
+				tierCR := &labelsv1alpha1.Namespacelabel{
+					ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: foreign.Name},
+					Spec: labelsv1alpha1.NamespacelabelSpec{
+						Labels:          map[string]string{"tier": "prod"},
+						OverwritePolicy: policy,
+					},
+				}
+				tierReconciler, tierRecorder := newTestReconciler(foreign, tierCR)
+
+				_, err := tierReconciler.Reconcile(ctx, requestFor(tierCR))
+				Expect(err).NotTo(HaveOccurred())
+				recorded := drainEvents(tierRecorder)
+				if wantEvent == "" {
+					Expect(recorded).NotTo(ContainElement(ContainSubstring("ExistingLabelOverwritten")))
+					Expect(recorded).NotTo(ContainElement(ContainSubstring("DuplicateLabelSkipped")))
+				} else {
+					Expect(recorded).To(ContainElement(ContainSubstring(wantEvent)))
+				}
+				Expect(tierReconciler.Get(ctx, types.NamespacedName{Name: foreign.Name}, foreign)).To(Succeed())
+				Expect(foreign.Labels).To(HaveKeyWithValue("tier", wantValue))
+			},
+			Entry("case-insensitive: a duplicate", true, labelsv1alpha1.OverwritePolicySkip, "DuplicateLabelSkipped", "Prod"),
+			Entry("case-sensitive: a duplicate", false, labelsv1alpha1.OverwritePolicySkip, "DuplicateLabelSkipped", "Prod"),
+			Entry("case-insensitive with Overwrite: kept as is", true, labelsv1alpha1.OverwritePolicyOverwrite, "", "Prod"),
+			Entry("case-sensitive with Overwrite: overwritten", false, labelsv1alpha1.OverwritePolicyOverwrite, "ExistingLabelOverwritten", "prod"),
+		)
+	})
+
+	Context("Per-label applied times", func() {
+		It("should keep each key's first applied time across reconciles and start over when it is applied again", func() {
+			start := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+			fakeClock := clocktesting.NewFakePassiveClock(start)
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform", "env": "prod"}})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			reconciler.Clock = fakeClock
+			request := requestFor(labelsCR)
+			reconcileAppliedAt := func() map[string]metav1.Time {
+				mustReconcile(reconciler, request)
+				Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+				return labelsCR.Status.AppliedAt
+			}
+			updateSpec := func(mutate func(map[string]string)) {
+				Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+				mutate(labelsCR.Spec.Labels)
+				Expect(reconciler.Update(ctx, labelsCR)).To(Succeed())
+			}
+
+			By("Applying both labels")
+			appliedAt := reconcileAppliedAt()
+			Expect(appliedAt).To(HaveLen(2))
+			Expect(appliedAt["team"].Time).To(BeTemporally("==", start))
+			Expect(appliedAt["env"].Time).To(BeTemporally("==", start))
+
+			By("Reconciling an hour later and verifying the times are kept")
+			fakeClock.SetTime(start.Add(time.Hour))
+			appliedAt = reconcileAppliedAt()
+			Expect(appliedAt["team"].Time).To(BeTemporally("==", start))
+			Expect(appliedAt["env"].Time).To(BeTemporally("==", start))
+
+			By("Dropping env from the spec and the namespace")
+			updateSpec(func(specLabels map[string]string) { delete(specLabels, "env") })
+			Expect(reconcileAppliedAt()).NotTo(HaveKey("env"))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			delete(namespace.Labels, "env")
+			Expect(reconciler.Update(ctx, namespace)).To(Succeed())
+
+			By("Applying env again and verifying its time starts over")
+			fakeClock.SetTime(start.Add(2 * time.Hour))
+			updateSpec(func(specLabels map[string]string) { specLabels["env"] = "prod" })
+			appliedAt = reconcileAppliedAt()
+			Expect(appliedAt["team"].Time).To(BeTemporally("==", start))
+			Expect(appliedAt["env"].Time).To(BeTemporally("==", start.Add(2*time.Hour)))
+		})
+	})
+
+	Context("Namespace annotations", func() {
+		var (
+			namespace *corev1.Namespace
+			labelsCR  *labelsv1alpha1.Namespacelabel
+			request   ctrl.Request
+		)
+
+		BeforeEach(func() {
+			DeferCleanup(os.Setenv, labels.ProtectedAnnotationsEnv, os.Getenv(labels.ProtectedAnnotationsEnv))
+			Expect(os.Setenv(labels.ProtectedAnnotationsEnv, `{"owner.example.com/billing": ""}`)).To(Succeed())
+
+			namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        NamespaceName,
+				Annotations: map[string]string{"scheduler.alpha.kubernetes.io/node-selector": "pool=general"},
+			}}
+			labelsCR = newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform"},
+				Annotations: map[string]string{
+					"openshift.io/description":                    "Platform team",
+					"owner.example.com/billing":                   "cc-1234",
+					"scheduler.alpha.kubernetes.io/node-selector": "pool=platform",
+				},
+			})
+			request = requestFor(labelsCR)
+		})
+
+		It("should apply annotations and record them in status", func() {
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+
+			By("Reconciling the Namespacelabel CR")
+			mustReconcile(reconciler, request)
+
+			By("Verifying only the unprotected, new annotation was applied")
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue("openshift.io/description", "Platform team"))
+			Expect(updated.Annotations).NotTo(HaveKey("owner.example.com/billing"))
+			Expect(updated.Annotations).To(HaveKeyWithValue("scheduler.alpha.kubernetes.io/node-selector", "pool=general"))
+			Expect(updated.Labels).To(HaveKeyWithValue("team", "platform"))
+
+			By("Verifying status and events")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedAnnotations).To(Equal(map[string]string{"openshift.io/description": "Platform team"}))
+			Expect(labelsCR.Status.SkippedAnnotations).To(Equal(map[string]string{"owner.example.com/billing": "cc-1234"}))
+			Eventually(recorder.Events).Should(Receive(Equal("Warning ProtectedAnnotationSkipped Annotation owner.example.com/billing=cc-1234 is protected and was not applied")))
+			Eventually(recorder.Events).Should(Receive(Equal("Warning DuplicateAnnotationSkipped Annotation scheduler.alpha.kubernetes.io/node-selector=pool=platform already exists with value pool=general")))
+		})
+
+		It("should update an applied annotation when its value changes", func() {
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Changing the annotation in the spec")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			labelsCR.Spec.Annotations["openshift.io/description"] = "Platform and SRE teams"
+			Expect(reconciler.Update(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+
+			By("Verifying the namespace and status carry the new value")
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue("openshift.io/description", "Platform and SRE teams"))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedAnnotations).To(Equal(map[string]string{"openshift.io/description": "Platform and SRE teams"}))
+		})
+
+		It("should remove only the annotations it applied on deletion", func() {
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Deleting the Namespacelabel")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(reconciler.Delete(ctx, labelsCR)).To(Succeed())
+			mustReconcile(reconciler, request)
+
+			By("Verifying the applied annotation is gone and the pre-existing one is kept")
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Annotations).NotTo(HaveKey("openshift.io/description"))
+			Expect(updated.Annotations).To(HaveKeyWithValue("scheduler.alpha.kubernetes.io/node-selector", "pool=general"))
+			Expect(updated.Labels).NotTo(HaveKey("team"))
+		})
+
+		It("should fail the reconcile on a malformed PROTECTED_ANNOTATIONS", func() {
+			Expect(os.Setenv(labels.ProtectedAnnotationsEnv, "{not json")).To(Succeed())
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).To(HaveOccurred())
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionConfigLoaded))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		})
+	})
+
+	Context("Batched status writes", func() {
+		It("should write each Namespacelabel's status once for a burst of reconciles", func() {
+			namespace := newNamespace(map[string]string{"app": "web"})
+			objs := []client.Object{namespace}
+			var requests []ctrl.Request
+			for i := range 3 {
+				labelsCR := &labelsv1alpha1.Namespacelabel{
+					ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("observer-%d", i), Namespace: NamespaceName},
+					Spec:       labelsv1alpha1.NamespacelabelSpec{ObserveOnly: true},
+				}
+				objs = append(objs, labelsCR)
+				requests = append(requests, requestFor(labelsCR))
+			}
+			var statusUpdates atomic.Int32
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					statusUpdates.Add(1)
+					return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+				},
+			}, objs...)
+			reconciler.StatusBatchWindow = time.Hour
+
+			By("Reconciling every Namespacelabel after each of several namespace changes")
+			for _, env := range []string{"dev", "staging", "prod"} {
+				Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+				namespace.Labels["env"] = env
+				Expect(reconciler.Update(ctx, namespace)).To(Succeed())
+				for _, request := range requests {
+					mustReconcile(reconciler, request)
+				}
+			}
+			Expect(statusUpdates.Load()).To(BeZero())
+
+			By("Flushing the batch")
+			reconciler.flushStatusBatch(ctx, NamespaceName)
+
+			By("Verifying one status write per Namespacelabel, carrying the latest status")
+			Expect(statusUpdates.Load()).To(BeEquivalentTo(len(requests)))
+			for _, request := range requests {
+				labelsCR := &labelsv1alpha1.Namespacelabel{}
+				Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+				Expect(labelsCR.Status.ObservedLabels).To(Equal(map[string]string{"app": "web", "env": "prod"}))
+			}
+		})
+
+		It("should requeue the reconcile until its queued status is written", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			reconciler.StatusBatchWindow = time.Hour
+			request := requestFor(labelsCR)
+
+			By("Reconciling while the status is queued")
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.statusQueued(request.NamespacedName)).To(BeTrue())
+			Expect(result.RequeueAfter).To(Equal(time.Hour))
+
+			By("Reconciling again once the batch was flushed")
+			reconciler.flushStatusBatch(ctx, NamespaceName)
+			result, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.statusQueued(request.NamespacedName)).To(BeFalse())
+			Expect(result.RequeueAfter).To(BeZero())
+		})
+
+		It("should write the queued statuses when the manager stops", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			reconciler.StatusBatchWindow = time.Hour
+			request := requestFor(labelsCR)
+
+			runCtx, stop := context.WithCancel(ctx)
+			done := make(chan error)
+			go func() { done <- reconciler.runStatusBatches(runCtx) }()
+
+			mustReconcile(reconciler, request)
+			Expect(reconciler.statusQueued(request.NamespacedName)).To(BeTrue())
+
+			By("Stopping the runnable before the window ends")
+			stop()
+			Eventually(done).Should(Receive(BeNil()))
+
+			By("Verifying the status was written")
+			Expect(reconciler.statusQueued(request.NamespacedName)).To(BeFalse())
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(Equal(map[string]string{"team": "platform"}))
+		})
+
+		It("should keep the failure count reported after the status was queued", func() {
+			DeferCleanup(os.Setenv, MaxReconcileFailuresEnv, os.Getenv(MaxReconcileFailuresEnv))
+			Expect(os.Setenv(MaxReconcileFailuresEnv, "2")).To(Succeed())
+
+			namespace := newNamespace(nil)
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName, Generation: 1},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}},
+			}
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			reconciler.StatusBatchWindow = time.Hour
+			reconciler.ProtectedConfigMap = types.NamespacedName{Namespace: "operator-system", Name: "missing"}
+			request := requestFor(labelsCR)
+
+			By("Failing to load the protected labels and flushing the queued status")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).To(HaveOccurred())
+			reconciler.flushStatusBatch(ctx, NamespaceName)
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.ConsecutiveFailures).To(BeEquivalentTo(1))
+			Expect(meta.IsStatusConditionTrue(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionReconcileError))).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionConfigLoaded))).To(BeTrue())
+
+			By("Failing again and verifying the bound is reached")
+			mustReconcile(reconciler, request)
+			reconciler.flushStatusBatch(ctx, NamespaceName)
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.ConsecutiveFailures).To(BeEquivalentTo(2))
+			Expect(labelsCR.Status.Phase).To(Equal(labelsv1alpha1.PhaseFailed))
+		})
+
+		It("should write status immediately without a batch window", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}})
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			mustReconcile(reconciler, requestFor(labelsCR))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(Equal(map[string]string{"team": "platform"}))
+		})
+	})
+
+	Context("Sticky mode", func() {
+		var (
+			fakeClock *clocktesting.FakePassiveClock
+			labelsCR  *labelsv1alpha1.Namespacelabel
+			request   ctrl.Request
+		)
+
+		BeforeEach(func() {
+			DeferCleanup(os.Setenv, StickyGracePeriodEnv, os.Getenv(StickyGracePeriodEnv))
+			Expect(os.Setenv(StickyGracePeriodEnv, "10m")).To(Succeed())
+			fakeClock = clocktesting.NewFakePassiveClock(time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC))
+			labelsCR = newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform", "env": "prod"}})
+			request = requestFor(labelsCR)
+		})
+
+		// applyAndDelete applies the Namespacelabel's labels, then deletes it and reconciles the deletion.
+		applyAndDelete := func(reconciler *NamespacelabelReconciler) {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			current := &labelsv1alpha1.Namespacelabel{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, current)).To(Succeed())
+			Expect(reconciler.Delete(ctx, current)).To(Succeed())
+			mustReconcile(reconciler, request)
+			Expect(errors.IsNotFound(reconciler.Get(ctx, request.NamespacedName, current))).To(BeTrue())
+		}
+
+		// recreate creates the Namespacelabel again with the given labels and reconciles it.
+		recreate := func(reconciler *NamespacelabelReconciler, desired map[string]string) {
+			recreated := newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: desired})
+			Expect(reconciler.Create(ctx, recreated)).To(Succeed())
+			mustReconcile(reconciler, request)
+		}
+
+		It("should let an identical recreated Namespacelabel adopt the labels without rewriting them", func() {
+			namespaceWrites := 0
+			reconciler, recorder := newInterceptedTestReconciler(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok {
+						namespaceWrites++
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}, newNamespace(nil), labelsCR)
+			reconciler.Clock = fakeClock
+
+			By("Deleting the Namespacelabel")
+			applyAndDelete(reconciler)
+
+			By("Verifying the labels were kept and recorded")
+			namespace := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("env", "prod"))
+			records, err := labels.StickyRecords(namespace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveKey(request.NamespacedName.String()))
+
+			By("Recreating the same Namespacelabel within the grace period")
+			fakeClock.SetTime(fakeClock.Now().Add(5 * time.Minute))
+			namespaceWrites = 0
+			recreate(reconciler, map[string]string{"team": "platform", "env": "prod"})
+
+			By("Verifying the labels were adopted with only the record dropped")
+			Expect(namespaceWrites).To(Equal(1))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("env", "prod"))
+			Expect(namespace.Annotations).NotTo(HaveKey(labels.StickyAnnotation))
+			recreated := &labelsv1alpha1.Namespacelabel{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, recreated)).To(Succeed())
+			Expect(recreated.Status.AppliedLabels).To(Equal(map[string]string{"team": "platform", "env": "prod"}))
+			Expect(meta.IsStatusConditionTrue(recreated.Status.Conditions, string(labelsv1alpha1.ConditionDuplicateLabels))).To(BeFalse())
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("StickyLabelsAdopted Adopted 2 labels")))
+		})
+
+		It("should remove kept labels the recreated Namespacelabel no longer wants", func() {
+			reconciler, _ := newTestReconciler(newNamespace(nil), labelsCR)
+			reconciler.Clock = fakeClock
+			applyAndDelete(reconciler)
+
+			recreate(reconciler, map[string]string{"team": "platform"})
+
+			namespace := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(namespace.Labels).NotTo(HaveKey("env"))
+			Expect(namespace.Annotations).NotTo(HaveKey(labels.StickyAnnotation))
+		})
+
+		It("should remove kept labels once the grace period ends through a reconcile of the namespace", func() {
+			reconciler, _ := newTestReconciler(newNamespace(nil), labelsCR)
+			reconciler.Clock = fakeClock
+			applyAndDelete(reconciler)
+
+			By("Mapping the namespace, as the watch does on every event and on startup")
+			namespace := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			sticky := stickyRequest(NamespaceName)
+			Expect(reconciler.enqueueRequestsFromNamespace(ctx, namespace)).To(ContainElement(sticky))
+
+			By("Reconciling the sticky request within the grace period")
+			result, err := reconciler.Reconcile(ctx, sticky)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+
+			By("Reconciling the sticky request once the grace period passed")
+			fakeClock.SetTime(fakeClock.Now().Add(11 * time.Minute))
+			result, err = reconciler.Reconcile(ctx, sticky)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			By("Verifying the labels and the record are gone")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("team"))
+			Expect(namespace.Labels).NotTo(HaveKey("env"))
+			Expect(namespace.Annotations).NotTo(HaveKey(labels.StickyAnnotation))
+		})
+
+		It("should keep labels changed out-of-band after the deletion", func() {
+			reconciler, _ := newTestReconciler(newNamespace(nil), labelsCR)
+			reconciler.Clock = fakeClock
+			applyAndDelete(reconciler)
+
+			By("Changing a kept label")
+			namespace := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			namespace.Labels["env"] = "staging"
+			Expect(reconciler.Update(ctx, namespace)).To(Succeed())
+
+			fakeClock.SetTime(fakeClock.Now().Add(11 * time.Minute))
+			mustReconcile(reconciler, stickyRequest(NamespaceName))
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).NotTo(HaveKey("team"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("env", "staging"))
+		})
+	})
+
+	Context("Overwrite policy", func() {
+		var (
+			namespace *corev1.Namespace
+			labelsCR  *labelsv1alpha1.Namespacelabel
+			request   ctrl.Request
+		)
+
+		BeforeEach(func() {
+			namespace = newNamespace(map[string]string{"team": "legacy", "protected-label": "original"})
+			labelsCR = newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{
+				Labels: map[string]string{"team": "platform", "env": "prod", "protected-label": "value"},
+			})
+			request = requestFor(labelsCR)
+		})
+
+		It("should skip existing labels as duplicates by default", func() {
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			mustReconcile(reconciler, request)
+
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "legacy"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("env", "prod"))
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(Equal(map[string]string{"env": "prod"}))
+			Expect(meta.IsStatusConditionTrue(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionDuplicateLabels))).To(BeTrue())
+		})
+
+		It("should overwrite existing labels but still skip protected ones under the Overwrite policy", func() {
+			labelsCR.Spec.OverwritePolicy = labelsv1alpha1.OverwritePolicyOverwrite
+			reconciler, recorder := newTestReconciler(namespace, labelsCR)
+
+			mustReconcile(reconciler, request)
+
+			By("Verifying the existing label was overwritten and the protected one left alone")
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("env", "prod"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("protected-label", "original"))
+			Eventually(recorder.Events).Should(Receive(Equal(fmt.Sprintf("Normal ExistingLabelOverwritten Label team on namespace %s was changed from legacy to platform under the Overwrite policy", NamespaceName))))
+
+			By("Verifying status reports no duplicates and the protected key as skipped")
+			Expect(reconciler.Get(ctx, request.NamespacedName, labelsCR)).To(Succeed())
+			Expect(labelsCR.Status.AppliedLabels).To(Equal(map[string]string{"team": "platform", "env": "prod"}))
+			Expect(labelsCR.Status.SkipReasons).To(HaveKeyWithValue("protected-label", labelsv1alpha1.SkipReasonProtected))
+			condition := meta.FindStatusCondition(labelsCR.Status.Conditions, string(labelsv1alpha1.ConditionDuplicateLabels))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		})
+	})
+
+	Context("Protected labels ConfigMap", func() {
+		protectedKey := types.NamespacedName{Name: "protected-labels", Namespace: "operator-system"}
+		newProtectedConfigMap := func(data string) *corev1.ConfigMap {
+			return &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: protectedKey.Name, Namespace: protectedKey.Namespace},
+				Data:       map[string]string{labels.ProtectedConfigMapDataKey: data},
+			}
+		}
+		newProtectedCR := func() *labelsv1alpha1.Namespacelabel {
+			return newNamespacelabel(labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{
+				"protected-label": "value",
+				"configmap-label": "value",
+				"team":            "platform",
+			}})
+		}
+
+		It("should read the environment variable when no ConfigMap is configured", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newProtectedCR()
+			reconciler, _ := newTestReconciler(namespace, labelsCR, newProtectedConfigMap(`{"configmap-label": ""}`))
+
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Labels).NotTo(HaveKey("protected-label"))
+			Expect(updated.Labels).To(HaveKeyWithValue("configmap-label", "value"))
+			Expect(updated.Labels).To(HaveKeyWithValue("team", "platform"))
+		})
+
+		It("should read the ConfigMap when the environment variable is not set", func() {
+			DeferCleanup(os.Setenv, labels.ProtectedLabelsEnv, os.Getenv(labels.ProtectedLabelsEnv))
+			Expect(os.Unsetenv(labels.ProtectedLabelsEnv)).To(Succeed())
+
+			namespace := newNamespace(nil)
+			labelsCR := newProtectedCR()
+			reconciler, _ := newTestReconciler(namespace, labelsCR, newProtectedConfigMap(`[{"key": "configmap-label"}]`))
+			reconciler.ProtectedConfigMap = protectedKey
+
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Labels).NotTo(HaveKey("configmap-label"))
+			Expect(updated.Labels).To(HaveKeyWithValue("protected-label", "value"))
+			Expect(updated.Labels).To(HaveKeyWithValue("team", "platform"))
+		})
+
+		It("should prefer the ConfigMap over the environment variable", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newProtectedCR()
+			reconciler, _ := newTestReconciler(namespace, labelsCR, newProtectedConfigMap(`{"configmap-label": ""}`))
+			reconciler.ProtectedConfigMap = protectedKey
+
+			mustReconcile(reconciler, requestFor(labelsCR))
+
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Labels).NotTo(HaveKey("configmap-label"))
+			Expect(updated.Labels).To(HaveKeyWithValue("protected-label", "value"))
+		})
+
+		It("should fail without touching the namespace when the ConfigMap is missing or malformed", func() {
+			namespace := newNamespace(map[string]string{"team": "platform"})
+			labelsCR := newProtectedCR()
+			labelsCR.Status.AppliedLabels = map[string]string{"team": "platform"}
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			reconciler.ProtectedConfigMap = protectedKey
+			request := requestFor(labelsCR)
+
+			By("Reconciling while the ConfigMap does not exist")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).To(MatchError(labels.ErrProtectedConfigMapNotFound))
+
+			updated := &labelsv1alpha1.Namespacelabel{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, updated)).To(Succeed())
+			condition := meta.FindStatusCondition(updated.Status.Conditions, string(labelsv1alpha1.ConditionConfigLoaded))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonProtectedConfigMissing)))
+
+			By("Reconciling once the ConfigMap is created malformed")
+			malformed := newProtectedConfigMap("{not json")
+			Expect(reconciler.Create(ctx, malformed)).To(Succeed())
+			Expect(reconciler.enqueueRequestsFromConfigMap(ctx, malformed)).To(ConsistOf(request))
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).To(MatchError(ContainSubstring("protected labels ConfigMap operator-system/protected-labels")))
+
+			Expect(reconciler.Get(ctx, request.NamespacedName, updated)).To(Succeed())
+			condition = meta.FindStatusCondition(updated.Status.Conditions, string(labelsv1alpha1.ConditionConfigLoaded))
+			Expect(condition.Reason).To(Equal(string(labelsv1alpha1.ReasonProtectedConfigInvalid)))
+
+			By("Verifying the namespace kept its labels and gained none")
+			unchanged := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, unchanged)).To(Succeed())
+			Expect(unchanged.Labels).To(Equal(map[string]string{"team": "platform"}))
+		})
+
+		It("should load the ConfigMap again after a transient failure", func() {
+			namespace := newNamespace(nil)
+			labelsCR := newProtectedCR()
+			var failedGets atomic.Int32
+			reconciler, _ := newInterceptedTestReconciler(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if _, ok := obj.(*corev1.ConfigMap); ok && key == protectedKey && failedGets.Load() == 0 {
+						failedGets.Add(1)
+						return errors.NewServiceUnavailable("apiserver is restarting")
+					}
+					return c.Get(ctx, key, obj, opts...)
+				},
+			}, namespace, labelsCR, newProtectedConfigMap(`[{"key": "configmap-label"}]`))
+			reconciler.ProtectedConfigMap = protectedKey
+			request := requestFor(labelsCR)
+
+			By("Reconciling while reading the ConfigMap fails")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).To(HaveOccurred())
+
+			By("Reconciling again without any change to the ConfigMap")
+			mustReconcile(reconciler, request)
+
+			updated := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: NamespaceName}, updated)).To(Succeed())
+			Expect(updated.Labels).NotTo(HaveKey("configmap-label"))
+			Expect(updated.Labels).To(HaveKeyWithValue("team", "platform"))
+		})
+	})
+
+	Context("Concurrent protected labels reloads", func() {
+		// Run with -race: the reloads swap the protected rules snapshot while the reconciles read it.
+		It("should give every reconcile a complete configuration while the ConfigMap is reloaded", func() {
+			protectedKey := types.NamespacedName{Name: "protected-labels", Namespace: "operator-system"}
+			configs := []string{
+				`[{"key": "protected-label"}, {"key": "first-label"}]`,
+				`[{"key": "protected-label"}, {"key": "second-label"}]`,
+			}
+			protectedConfigMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: protectedKey.Name, Namespace: protectedKey.Namespace},
+				Data:       map[string]string{labels.ProtectedConfigMapDataKey: configs[0]},
+			}
+			objects := []client.Object{protectedConfigMap}
+			var requests []ctrl.Request
+			for i := 0; i < 4; i++ {
+				name := fmt.Sprintf("concurrent-%d", i)
+				labelsCR := &labelsv1alpha1.Namespacelabel{
+					ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: name},
+					Spec: labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{
+						"protected-label": "value",
+						"team":            "platform",
+					}},
+				}
+				objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, labelsCR)
+				requests = append(requests, requestFor(labelsCR))
+			}
+			reconciler, _ := newTestReconciler(objects...)
+			reconciler.ProtectedConfigMap = protectedKey
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for i := 0; i < 50; i++ {
+					reloaded := protectedConfigMap.DeepCopy()
+					Expect(reconciler.Get(ctx, protectedKey, reloaded)).To(Succeed())
+					reloaded.Data[labels.ProtectedConfigMapDataKey] = configs[i%2]
+					Expect(reconciler.Update(ctx, reloaded)).To(Succeed())
+					reconciler.enqueueRequestsFromConfigMap(ctx, reloaded)
+				}
+			}()
+			for _, request := range requests {
+				wg.Add(1)
+				go func(request ctrl.Request) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					for i := 0; i < 20; i++ {
+						rules, err := reconciler.loadProtected(ctx)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(rules).To(HaveLen(2))
+						Expect(rules[0].Key).To(Equal("protected-label"))
+						mustReconcile(reconciler, request)
+					}
+				}(request)
+			}
+			wg.Wait()
+
+			By("Verifying the key protected by every configuration was never applied")
+			for _, request := range requests {
+				namespace := &corev1.Namespace{}
+				Expect(reconciler.Get(ctx, types.NamespacedName{Name: request.Namespace}, namespace)).To(Succeed())
+				Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+				Expect(namespace.Labels).NotTo(HaveKey("protected-label"))
+			}
+		})
+	})
+
+	Context("Label plan metrics", func() {
+		It("should count the applied, skipped and duplicate labels of an applied plan", func() {
+			const target = "plan-metrics"
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   target,
+				Labels: map[string]string{"owner": "someone-else"},
+			}}
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: target},
+				Spec: labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{
+					"team":            "platform",
+					"app":             "web",
+					"protected-label": "value",
+					"owner":           "platform",
+				}},
+			}
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+			request := requestFor(labelsCR)
+
+			applied := testutil.ToFloat64(metrics.AppliedLabels.WithLabelValues(target))
+			skipped := testutil.ToFloat64(metrics.SkippedLabels.WithLabelValues(target))
+			duplicates := testutil.ToFloat64(metrics.DuplicateLabels.WithLabelValues(target))
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(testutil.ToFloat64(metrics.AppliedLabels.WithLabelValues(target))).To(Equal(applied + 2))
+			Expect(testutil.ToFloat64(metrics.SkippedLabels.WithLabelValues(target))).To(Equal(skipped + 1))
+			Expect(testutil.ToFloat64(metrics.DuplicateLabels.WithLabelValues(target))).To(Equal(duplicates + 1))
+
+			By("Reconciling again with nothing to change")
+			mustReconcile(reconciler, request)
+			Expect(testutil.ToFloat64(metrics.AppliedLabels.WithLabelValues(target))).To(Equal(applied + 2))
+		})
+
+		It("should not count the labels of a plan that is not applied", func() {
+			const target = "plan-metrics-dry-run"
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: target}}
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: target},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}, DryRun: true},
+			}
+			reconciler, _ := newTestReconciler(namespace, labelsCR)
+
+			applied := testutil.ToFloat64(metrics.AppliedLabels.WithLabelValues(target))
+			mustReconcile(reconciler, requestFor(labelsCR))
+			Expect(testutil.ToFloat64(metrics.AppliedLabels.WithLabelValues(target))).To(Equal(applied))
+		})
+	})
+
+})
+
+// skipCase describes a reconcile that skips some of the desired labels.
+type skipCase struct {
+	// env holds environment variables set for the reconcile.
+	env                  map[string]string
+	namespaceLabels      map[string]string
+	namespaceAnnotations map[string]string
+	// annotations are set on the Namespacelabel.
+	annotations map[string]string
+	spec        labelsv1alpha1.NamespacelabelSpec
+	// applied holds the labels expected on the namespace afterwards.
+	applied     map[string]string
+	skipReasons map[string]labelsv1alpha1.SkipReason
+	// event is a substring of one of the recorded events.
+	event string
+}
+
+// drainEvents returns the events recorded so far, leaving the recorder empty.
+func drainEvents(recorder *record.FakeRecorder) []string {
+	recorded := drainEvents(recorder)
+	return recorded
+}
+
+// newTestReconciler builds a reconciler backed by a fake client seeded with the given objects.
+func newTestReconciler(objs ...client.Object) (*NamespacelabelReconciler, *record.FakeRecorder) {
+	return newInterceptedTestReconciler(interceptor.Funcs{}, objs...)
+}
+
+// newInterceptedTestReconciler builds a reconciler whose fake client routes calls through the given interceptors.
+func newInterceptedTestReconciler(funcs interceptor.Funcs, objs ...client.Object) (*NamespacelabelReconciler, *record.FakeRecorder) {
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	restMapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	restMapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithRESTMapper(restMapper).
+		WithObjects(objs...).
+		WithStatusSubresource(&labelsv1alpha1.Namespacelabel{}, &labelsv1alpha1.NamespacelabelSummary{}).
+		WithInterceptorFuncs(funcs).
+		Build()
+
+	recorder := record.NewFakeRecorder(100)
+	return &NamespacelabelReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme.Scheme,
+		Recorder: recorder,
+	}, recorder
+}
+
+// namespaceRecorder records events as "<involved object namespace> <reason> <message>".
+type namespaceRecorder struct {
+	events []string
+}
+
+func (n *namespaceRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	namespace := ""
+	if ref, ok := object.(*corev1.ObjectReference); ok {
+		namespace = ref.Namespace
+	} else if obj, ok := object.(client.Object); ok {
+		namespace = obj.GetNamespace()
+	}
+	n.events = append(n.events, fmt.Sprintf("%s %s %s", namespace, reason, message))
+}
+
+func (n *namespaceRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	n.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (n *namespaceRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	n.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// objectRecorder records events as "<involved object kind>/<name> <reason> <message>".
+type objectRecorder struct {
+	events []string
+}
+
+func (o *objectRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	kind := ""
+	switch object.(type) {
+	case *corev1.Namespace:
+		kind = "Namespace"
+	case *labelsv1alpha1.Namespacelabel:
+		kind = "Namespacelabel"
+	}
+	name := ""
+	if obj, ok := object.(client.Object); ok {
+		name = obj.GetName()
+	}
+	o.events = append(o.events, fmt.Sprintf("%s/%s %s %s", kind, name, reason, message))
+}
+
+func (o *objectRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	o.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (o *objectRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	o.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
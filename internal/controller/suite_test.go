@@ -16,6 +16,7 @@ import (
 	labelsv1alpha1 "github.com/matanamar10/namespacelabel-operator/api/v1alpha1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -31,6 +32,8 @@ var (
 	cancel        context.CancelFunc
 	protectedEnv  = "PROTECTED_LABELS"
 	protectedData = map[string]string{"protected-label": "protected-value"}
+	// eventRecorder receives the events of the reconciler run by the manager.
+	eventRecorder = record.NewFakeRecorder(1024)
 )
 
 func TestControllers(t *testing.T) {
@@ -77,8 +80,10 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 
 	err = (&NamespacelabelReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: k8sManager.GetScheme(),
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: eventRecorder,
+		Log:      ctrl.Log.WithName("namespacelabel-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -0,0 +1,68 @@
+package denylist
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapEnv names the environment variable holding the "namespace/name" of a ConfigMap listing namespaces the
+// operator must never act on. The ConfigMap is read on every use, so the list can change without a restart.
+const ConfigMapEnv = "DENY_NAMESPACES_CONFIGMAP"
+
+// NamespacesKey is the ConfigMap data key listing the denied namespaces, separated by commas or newlines.
+const NamespacesKey = "namespaces"
+
+// ConfigMapKey returns the configured deny ConfigMap, and false when none is configured.
+func ConfigMapKey() (types.NamespacedName, bool, error) {
+	value := os.Getenv(ConfigMapEnv)
+	if value == "" {
+		return types.NamespacedName{}, false, nil
+	}
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, false, fmt.Errorf("%s must be namespace/name, got %q", ConfigMapEnv, value)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, true, nil
+}
+
+// Load returns the denied namespaces. It returns an empty set when no deny ConfigMap is configured or it does
+// not exist yet.
+func Load(ctx context.Context, reader client.Reader) (map[string]bool, error) {
+	key, ok, err := ConfigMapKey()
+	if err != nil || !ok {
+		return map[string]bool{}, err
+	}
+
+	var configMap corev1.ConfigMap
+	if err := reader.Get(ctx, key, &configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return map[string]bool{}, nil
+		}
+		return nil, fmt.Errorf("failed to get deny ConfigMap %s: %w", key, err)
+	}
+	return Parse(configMap.Data[NamespacesKey]), nil
+}
+
+// Parse returns the namespaces listed in a deny ConfigMap's NamespacesKey value.
+func Parse(value string) map[string]bool {
+	denied := make(map[string]bool)
+	for _, namespace := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			denied[namespace] = true
+		}
+	}
+	return denied
+}
+
+// IsConfigMap reports whether the object is the configured deny ConfigMap.
+func IsConfigMap(object client.Object) bool {
+	key, ok, err := ConfigMapKey()
+	return err == nil && ok && key == client.ObjectKeyFromObject(object)
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/tools/reference"
+)
+
+// CentralNamespaceEnv names the environment variable holding a namespace that receives a mirror of every event
+// the controller records, for monitoring setups that watch a single namespace.
+const CentralNamespaceEnv = "CENTRAL_EVENT_NAMESPACE"
+
+// mirroringRecorder records each event on its object and mirrors it into a central namespace.
+type mirroringRecorder struct {
+	record.EventRecorder
+	scheme    *runtime.Scheme
+	namespace string
+	logger    logr.Logger
+}
+
+// NewMirroringRecorder returns a recorder that records events through recorder and also records a copy in namespace.
+// The copy references the original object by kind and name, with the message prefixed by the object's namespace/name.
+func NewMirroringRecorder(recorder record.EventRecorder, scheme *runtime.Scheme, namespace string, logger logr.Logger) record.EventRecorder {
+	return &mirroringRecorder{EventRecorder: recorder, scheme: scheme, namespace: namespace, logger: logger}
+}
+
+func (m *mirroringRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	m.EventRecorder.Event(object, eventtype, reason, message)
+	m.mirror(object, nil, eventtype, reason, message)
+}
+
+func (m *mirroringRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	m.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (m *mirroringRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	m.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	m.mirror(object, annotations, eventtype, reason, message)
+}
+
+// mirror records the event again against a reference to the object placed in the central namespace.
+func (m *mirroringRecorder) mirror(object runtime.Object, annotations map[string]string, eventtype, reason, message string) {
+	ref, err := reference.GetReference(m.scheme, object)
+	if err != nil {
+		m.logger.Error(err, "Failed to build a reference for the mirrored event", "reason", reason)
+		return
+	}
+	if ref.Namespace == m.namespace {
+		return
+	}
+
+	source := ref.Name
+	if ref.Namespace != "" {
+		source = ref.Namespace + "/" + ref.Name
+	}
+	mirrored := ref.DeepCopy()
+	mirrored.Namespace = m.namespace
+	m.EventRecorder.AnnotatedEventf(mirrored, annotations, eventtype, reason, "%s: %s", source, message)
+}
@@ -0,0 +1,108 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/matanamar10/namespacelabel-operator/internal/metrics"
+)
+
+// MaxEventsPerMinuteEnv names the environment variable capping how many events the controller records per object
+// per minute. Events beyond the cap are dropped and counted in the namespacelabel_dropped_events_total metric.
+const MaxEventsPerMinuteEnv = "MAX_EVENTS_PER_MINUTE"
+
+// sweepInterval is how often the buckets of objects that went quiet are dropped.
+const sweepInterval = 10 * time.Minute
+
+// rateLimitedRecorder records events through a token bucket per object, so a flapping object cannot flood the
+// event stream. Buckets are dropped once full again, so objects that are deleted or go quiet are not kept forever.
+type rateLimitedRecorder struct {
+	record.EventRecorder
+	perMinute int
+	logger    logr.Logger
+
+	mu        sync.Mutex
+	limiters  map[types.UID]*rate.Limiter
+	dropped   map[types.UID]int
+	lastSweep time.Time
+}
+
+// NewRateLimitedRecorder returns a recorder that records at most perMinute events per object per minute through
+// recorder, allowing bursts of up to perMinute events.
+func NewRateLimitedRecorder(recorder record.EventRecorder, perMinute int, logger logr.Logger) record.EventRecorder {
+	return &rateLimitedRecorder{
+		EventRecorder: recorder,
+		perMinute:     perMinute,
+		logger:        logger,
+		limiters:      make(map[types.UID]*rate.Limiter),
+		dropped:       make(map[types.UID]int),
+	}
+}
+
+func (r *rateLimitedRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.allow(object, reason) {
+		r.EventRecorder.Event(object, eventtype, reason, message)
+	}
+}
+
+func (r *rateLimitedRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *rateLimitedRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	if r.allow(object, reason) {
+		r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	}
+}
+
+// allow takes a token from the object's bucket, counting the event as dropped when none is left.
+// Objects without metadata are never limited.
+func (r *rateLimitedRecorder) allow(object runtime.Object, reason string) bool {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.sweep(now)
+	limiter, ok := r.limiters[accessor.GetUID()]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(r.perMinute)), r.perMinute)
+		r.limiters[accessor.GetUID()] = limiter
+	}
+	if limiter.AllowN(now, 1) {
+		return true
+	}
+
+	r.dropped[accessor.GetUID()]++
+	metrics.IncDroppedEvents(accessor.GetNamespace())
+	r.logger.V(1).Info("Dropping event over the rate limit", "namespace", accessor.GetNamespace(), "name", accessor.GetName(),
+		"reason", reason, "dropped", r.dropped[accessor.GetUID()])
+	return false
+}
+
+// sweep drops the buckets that filled up again, at most once per sweepInterval. A full bucket behaves like a new one,
+// so dropping it changes nothing but the dropped count logged for the object. The caller must hold mu.
+func (r *rateLimitedRecorder) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < sweepInterval {
+		return
+	}
+	r.lastSweep = now
+	for uid, limiter := range r.limiters {
+		if limiter.TokensAt(now) >= float64(r.perMinute) {
+			delete(r.limiters, uid)
+			delete(r.dropped, uid)
+		}
+	}
+}
@@ -1,13 +1,18 @@
 package finalizer
 
 import (
+	"encoding/json"
 	"fmt"
 	labelsv1alpha1 "github.com/matanamar10/namespacelabel-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"context"
+	"sort"
 
+	"github.com/matanamar10/namespacelabel-operator/internal/denylist"
 	"github.com/matanamar10/namespacelabel-operator/internal/labels"
+	"github.com/matanamar10/namespacelabel-operator/internal/propagation"
 
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,6 +23,10 @@ import (
 // This prevents Kubernetes from deleting the CR until the cleanup function completes.
 const finalizerName = "namespacelabels.finalizers.dana.io"
 
+// cleanupAttempts bounds how many times Cleanup removes the labels and re-reads the namespace
+// before giving up on a writer that keeps adding them back.
+const cleanupAttempts = 3
+
 // Ensure ensures that the specified finalizer is added to the Namespacelabel CR if it’s missing.
 // This makes sure that cleanup operations are triggered before deletion.
 func Ensure(ctx context.Context, c client.Client, obj client.Object, logger logr.Logger) error {
@@ -38,7 +47,9 @@ func Ensure(ctx context.Context, c client.Client, obj client.Object, logger logr
 // Cleanup actions, removing labels from the namespace associated with
 // the Namespacelabel CR, and then removes the finalizer itself.
 // Cleanup performs finalizer actions, cleaning up namespace labels and removing the finalizer.
-func Cleanup(ctx context.Context, c client.Client, obj client.Object, logger logr.Logger) error {
+// Namespace writes are attributed to the given field manager. The removal is verified by re-reading the
+// namespace through reader, which should bypass the cache so that a racing writer is noticed.
+func Cleanup(ctx context.Context, c client.Client, reader client.Reader, obj client.Object, fieldManager string, logger logr.Logger) error {
 	namespaceLabel, ok := obj.(*labelsv1alpha1.Namespacelabel)
 	if !ok {
 		return fmt.Errorf("unexpected type: expected *labelsv1.Namespacelabel, got %T", obj)
@@ -47,24 +58,157 @@ func Cleanup(ctx context.Context, c client.Client, obj client.Object, logger log
 	logger.Info("Starting cleanup for Namespacelabel", "namespaceLabel", namespaceLabel.Name)
 
 	var namespace corev1.Namespace
-	if err := c.Get(ctx, client.ObjectKey{Name: namespaceLabel.Namespace}, &namespace); err != nil {
-		logger.Error(err, "Failed to retrieve namespace for cleanup", "namespaceLabel", namespaceLabel.Name)
-		return fmt.Errorf("failed to retrieve namespace: %w", err)
+	namespaceGone := false
+	if err := reader.Get(ctx, client.ObjectKey{Name: TargetNamespace(namespaceLabel)}, &namespace); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to retrieve namespace for cleanup", "namespaceLabel", namespaceLabel.Name)
+			return fmt.Errorf("failed to retrieve namespace: %w", err)
+		}
+		namespaceGone = true
+	}
+
+	denied, err := denylist.Load(ctx, reader)
+	if err != nil {
+		logger.Error(err, "Failed to load the deny ConfigMap", "namespaceLabel", namespaceLabel.Name)
+		return err
 	}
 
-	labels.Cleanup(&namespace, namespaceLabel.Spec.Labels, logger)
+	switch {
+	case namespaceGone:
+		logger.Info("Namespace no longer exists, nothing to clean up", "namespace", TargetNamespace(namespaceLabel), "namespaceLabel", namespaceLabel.Name)
+	case !namespace.DeletionTimestamp.IsZero():
+		logger.Info("Namespace is being deleted, nothing to clean up", "namespace", namespace.Name, "namespaceLabel", namespaceLabel.Name)
+	case denied[namespace.Name]:
+		logger.Info("Namespace is denied, leaving its labels in place", "namespace", namespace.Name, "namespaceLabel", namespaceLabel.Name)
+	case labels.IsOptedOut(&namespace):
+		logger.Info("Namespace opted out of operator management, leaving its labels in place", "namespace", namespace.Name, "namespaceLabel", namespaceLabel.Name)
+	default:
+		if err := cleanupNamespace(ctx, c, reader, namespaceLabel, fieldManager, logger); err != nil {
+			return err
+		}
 
-	if err := c.Update(ctx, &namespace); err != nil {
-		logger.Error(err, "Failed to update namespace after cleanup", "namespaceLabel", namespaceLabel.Name)
-		return fmt.Errorf("failed to update namespace: %w", err)
+		if len(namespaceLabel.Spec.PropagateTo) > 0 {
+			if err := propagation.Cleanup(ctx, c, TargetNamespace(namespaceLabel), namespaceLabel.Spec.PropagateTo, managedLabels(namespaceLabel), fieldManager, logger); err != nil {
+				logger.Error(err, "Failed to clean up propagated labels", "namespaceLabel", namespaceLabel.Name)
+				return fmt.Errorf("failed to clean up propagated labels: %w", err)
+			}
+		}
 	}
 
+	return Remove(ctx, c, obj, logger)
+}
+
+// Remove removes the finalizer from the Namespacelabel CR without cleaning up its namespace, letting the deletion finish.
+func Remove(ctx context.Context, c client.Client, obj client.Object, logger logr.Logger) error {
 	controllerutil.RemoveFinalizer(obj, finalizerName)
 	if err := c.Update(ctx, obj); err != nil {
-		logger.Error(err, "Failed to remove finalizer", "namespaceLabel", namespaceLabel.Name)
+		logger.Error(err, "Failed to remove finalizer", "namespaceLabel", obj.GetName())
 		return fmt.Errorf("failed to remove finalizer: %w", err)
 	}
 
-	logger.Info("Finalizer removed successfully", "finalizer", finalizerName, "namespaceLabel", namespaceLabel.Name)
+	logger.Info("Finalizer removed successfully", "finalizer", finalizerName, "namespaceLabel", obj.GetName())
 	return nil
 }
+
+// cleanupNamespace removes the Namespacelabel's labels and annotations from its namespace and verifies they are gone,
+// retrying up to cleanupAttempts times when a racing writer re-adds any of them.
+func cleanupNamespace(ctx context.Context, c client.Client, reader client.Reader, namespaceLabel *labelsv1alpha1.Namespacelabel, fieldManager string, logger logr.Logger) error {
+	key := client.ObjectKey{Name: TargetNamespace(namespaceLabel)}
+	for attempt := 1; attempt <= cleanupAttempts; attempt++ {
+		var namespace corev1.Namespace
+		if err := reader.Get(ctx, key, &namespace); err != nil {
+			logger.Error(err, "Failed to retrieve namespace for cleanup", "namespaceLabel", namespaceLabel.Name)
+			return fmt.Errorf("failed to retrieve namespace: %w", err)
+		}
+
+		managed := managedLabels(namespaceLabel)
+		remaining := remainingKeys(namespace.Labels, managed)
+		remainingAnnotations := remainingKeys(namespace.Annotations, namespaceLabel.Status.AppliedAnnotations)
+		ownsTimestamp := labels.OwnsApplyTimestamp(&namespace, namespaceLabel.Status.AppliedTimestamp)
+		specHashKey := labels.SpecHashLabelKey(namespaceLabel.Namespace, namespaceLabel.Name)
+		if len(remaining) == 0 && len(remainingAnnotations) == 0 && !ownsTimestamp &&
+			namespace.Labels[specHashKey] == "" && !labels.HasMirrors(&namespace, managed) {
+			return nil
+		}
+		if attempt > 1 {
+			logger.Info("Labels reappeared on the namespace after cleanup, retrying", "namespaceLabel", namespaceLabel.Name, "keys", remaining, "annotations", remainingAnnotations, "attempt", attempt)
+		}
+
+		original := namespace.DeepCopy()
+		labels.Cleanup(&namespace, managed, logger)
+		for _, key := range remainingAnnotations {
+			logger.Info("Removing annotation", "key", key)
+			delete(namespace.Annotations, key)
+		}
+		if ownsTimestamp {
+			delete(namespace.Annotations, labels.LastAppliedAnnotation)
+		}
+		delete(namespace.Labels, specHashKey)
+
+		if err := c.Patch(ctx, &namespace, client.MergeFrom(original), client.FieldOwner(fieldManager)); err != nil {
+			logger.Error(err, "Failed to patch namespace after cleanup", "namespaceLabel", namespaceLabel.Name)
+			return fmt.Errorf("failed to patch namespace: %w", err)
+		}
+	}
+
+	var namespace corev1.Namespace
+	if err := reader.Get(ctx, key, &namespace); err != nil {
+		return fmt.Errorf("failed to verify namespace cleanup: %w", err)
+	}
+	if remaining := remainingKeys(namespace.Labels, managedLabels(namespaceLabel)); len(remaining) > 0 {
+		return fmt.Errorf("labels %v are still present on namespace %s after %d cleanup attempts", remaining, namespace.Name, cleanupAttempts)
+	}
+	if remaining := remainingKeys(namespace.Annotations, namespaceLabel.Status.AppliedAnnotations); len(remaining) > 0 {
+		return fmt.Errorf("annotations %v are still present on namespace %s after %d cleanup attempts", remaining, namespace.Name, cleanupAttempts)
+	}
+	return nil
+}
+
+// managedLabels returns the labels the finalizer removes: those the controller actually applied, as recorded in
+// status.appliedLabels and, when the last status write failed, in the pending-status annotation. Keys in spec.labels
+// that were skipped, such as duplicates of labels the namespace already had, were never applied and are left alone.
+// Values redacted in status are taken from the spec where it has them, for the value checks of propagation cleanup.
+func managedLabels(namespaceLabel *labelsv1alpha1.Namespacelabel) map[string]string {
+	managed := make(map[string]string, len(namespaceLabel.Status.AppliedLabels))
+	for key, value := range namespaceLabel.Status.AppliedLabels {
+		managed[key] = value
+	}
+	if pending := namespaceLabel.Annotations[labels.PendingStatusAnnotation]; pending != "" {
+		var pendingLabels map[string]string
+		if err := json.Unmarshal([]byte(pending), &pendingLabels); err == nil {
+			for key, value := range pendingLabels {
+				managed[key] = value
+			}
+		}
+	}
+
+	specLabels := labels.WithAliases(namespaceLabel.Spec.Labels, namespaceLabel.Spec.Aliases)
+	for key, value := range managed {
+		if specValue, ok := specLabels[key]; ok && value == labels.RedactedValue {
+			managed[key] = specValue
+		}
+	}
+	return managed
+}
+
+// remainingKeys returns the sorted keys of toRemove that are still set in present, the namespace's labels or
+// annotations.
+func remainingKeys(present, toRemove map[string]string) []string {
+	var remaining []string
+	for key := range toRemove {
+		if _, ok := present[key]; ok {
+			remaining = append(remaining, key)
+		}
+	}
+	sort.Strings(remaining)
+	return remaining
+}
+
+// TargetNamespace returns the namespace the Namespacelabel last applied labels to,
+// falling back to its own namespace when none was recorded.
+func TargetNamespace(namespaceLabel *labelsv1alpha1.Namespacelabel) string {
+	if namespaceLabel.Status.TargetNamespace != "" {
+		return namespaceLabel.Status.TargetNamespace
+	}
+	return namespaceLabel.Namespace
+}
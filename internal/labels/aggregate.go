@@ -0,0 +1,44 @@
+package labels
+
+import (
+	"sort"
+
+	labelsv1alpha1 "github.com/matanamar10/namespacelabel-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ManagedLabelsAnnotation is the namespace annotation holding, as a JSON object, every label applied to the
+// namespace by any Namespacelabel, keyed by label key, with its value and the Namespacelabel that owns it.
+const ManagedLabelsAnnotation = "labels.dana.io/managed-labels"
+
+// ManagedLabel is one entry of the ManagedLabelsAnnotation.
+type ManagedLabel struct {
+	Value string `json:"value"`
+	// Owner is the "namespace/name" of the Namespacelabel that applied the label.
+	Owner string `json:"owner"`
+}
+
+// Aggregate returns the union of the labels the given Namespacelabels applied to the namespace, with their owners.
+// Namespacelabels being deleted are left out. When two of them applied the same key, the first by namespace and name
+// owns it.
+func Aggregate(namespaceLabels []labelsv1alpha1.Namespacelabel, namespace string) map[string]ManagedLabel {
+	sorted := make([]labelsv1alpha1.Namespacelabel, len(namespaceLabels))
+	copy(sorted, namespaceLabels)
+	sort.Slice(sorted, func(i, j int) bool {
+		return client.ObjectKeyFromObject(&sorted[i]).String() < client.ObjectKeyFromObject(&sorted[j]).String()
+	})
+
+	managed := make(map[string]ManagedLabel)
+	for i := range sorted {
+		namespaceLabel := &sorted[i]
+		if namespaceLabel.Status.TargetNamespace != namespace || !namespaceLabel.DeletionTimestamp.IsZero() {
+			continue
+		}
+		for key, value := range namespaceLabel.Status.AppliedLabels {
+			if _, ok := managed[key]; !ok {
+				managed[key] = ManagedLabel{Value: value, Owner: client.ObjectKeyFromObject(namespaceLabel).String()}
+			}
+		}
+	}
+	return managed
+}
@@ -1,32 +1,389 @@
 package labels
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"encoding/json"
 	"os"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // The ProtectedLabelsEnv const is represented the protected labels for all the namespaces in the k8s cluster.
 // Those labels keys and values can't be overridden by any namespacelabel object in any namespace.
 const ProtectedLabelsEnv = "PROTECTED_LABELS"
 
-// LoadProtected loads a set of "protected" labels from an environment variable.
-func LoadProtected(logger logr.Logger) (map[string]string, error) {
+// ProtectedAnnotationsEnv names the environment variable protecting namespace annotations from spec.annotations.
+// It accepts the same formats as ProtectedLabelsEnv. Unlike it, it is optional: when unset, no annotation is protected.
+const ProtectedAnnotationsEnv = "PROTECTED_ANNOTATIONS"
+
+// ErrProtectedNotSet is returned by LoadProtected when the PROTECTED_LABELS environment variable is not set.
+var ErrProtectedNotSet = errors.New("PROTECTED_LABELS environment variable is not set")
+
+// ProtectedConfigMapDataKey is the data key of the protected labels ConfigMap. It holds the same JSON as
+// ProtectedLabelsEnv.
+const ProtectedConfigMapDataKey = "protected-labels.json"
+
+// ErrProtectedConfigMapNotFound is returned by LoadProtectedFromConfigMap when the ConfigMap does not exist.
+var ErrProtectedConfigMapNotFound = errors.New("protected labels ConfigMap not found")
+
+// ReservedPrefix is the key prefix the operator keeps for its own labels and annotations, such as LastAppliedAnnotation.
+// Namespacelabels may not request keys under it.
+const ReservedPrefix = "labels.dana.io/"
+
+// IsReserved reports whether the key is under ReservedPrefix.
+func IsReserved(key string) bool {
+	return strings.HasPrefix(key, ReservedPrefix)
+}
+
+// LastAppliedAnnotation is the namespace annotation holding the RFC3339 time of the last successful apply.
+const LastAppliedAnnotation = "labels.dana.io/last-applied"
+
+// SpecHashLabelPrefix prefixes the namespace labels holding a short hash of the labels a Namespacelabel applied, when
+// it sets spec.recordSpecHash. External tools can watch the one label of a Namespacelabel to detect changes.
+const SpecHashLabelPrefix = "labels.dana.io/spec-hash-"
+
+// specHashLength is how many hex characters of the checksum SpecHash keeps.
+const specHashLength = 10
+
+// ExcludeAnnotation is the namespace annotation listing comma-separated label keys that no Namespacelabel may set
+// on that namespace. It lets namespace owners block specific keys.
+const ExcludeAnnotation = "labels.dana.io/exclude"
+
+// YieldKeysEnv names the environment variable listing comma-separated label keys on which the operator yields to
+// other writers, such as policy engines mutating namespaces. Once a yielded key is applied, a different value set on
+// the namespace by someone else is kept instead of reverted.
+const YieldKeysEnv = "YIELD_LABEL_KEYS"
+
+// CaseInsensitiveValuesEnv names the environment variable that, when "true", makes the operator compare label values
+// case-insensitively: a namespace value differing from the desired one only in case, such as Prod and prod, counts as
+// the same value and is kept rather than reported as drift and rewritten.
+const CaseInsensitiveValuesEnv = "CASE_INSENSITIVE_VALUES"
+
+// DeprecatedKeysEnv names the environment variable listing comma-separated label keys that are deprecated.
+// Deprecated keys are still applied, but Namespacelabels using them are warned so teams can migrate.
+const DeprecatedKeysEnv = "DEPRECATED_LABEL_KEYS"
+
+// ConfirmEmptySpecAnnotation is the Namespacelabel annotation confirming that an empty spec.labels is intended.
+// It is only consulted when the empty spec guard is enabled.
+const ConfirmEmptySpecAnnotation = "labels.dana.io/confirm-empty-spec"
+
+// PendingStatusAnnotation is the Namespacelabel annotation holding, as a JSON object, the labels applied to the
+// namespace by a reconcile whose status write failed. The next reconcile restores status from it instead of
+// treating those labels as foreign.
+const PendingStatusAnnotation = "labels.dana.io/pending-applied-labels"
+
+// DryRunPlanAnnotation is the Namespacelabel annotation holding, as JSON, the plan computed in dry-run mode.
+const DryRunPlanAnnotation = "labels.dana.io/dry-run-plan"
+
+// ExemptProtectedAnnotation is the Namespacelabel annotation that, when "true", lets the CR apply protected labels.
+// The webhook only admits setting it for members of the privileged groups.
+const ExemptProtectedAnnotation = "labels.dana.io/exempt-protected"
+
+// ForceReconcileAnnotation is the Namespacelabel annotation that, when set to a value not yet handled, makes the next
+// reconcile re-emit the current-state events even if nothing changed. Any new nonce triggers it again.
+const ForceReconcileAnnotation = "labels.dana.io/force-reconcile"
+
+// OptOutAnnotation is the namespace annotation that, when "true", makes the operator leave the namespace alone:
+// no Namespacelabel applies labels to it or removes labels from it.
+const OptOutAnnotation = "labels.dana.io/opt-out"
+
+// MirrorAnnotationPrefix prefixes the namespace annotations that mirror applied labels.
+const MirrorAnnotationPrefix = "labels.dana.io/applied."
+
+// qualifiedNameMaxLength is the longest name part, after the prefix, that a label or annotation key may have.
+const qualifiedNameMaxLength = 63
+
+// ProtectedRule protects a label key, optionally only in namespaces matching a selector.
+type ProtectedRule struct {
+	// Key is the protected label key. A trailing "*" protects every key starting with the preceding prefix.
+	Key string `json:"key"`
+
+	// NamespaceSelector limits the rule to namespaces whose labels match it. When empty, the rule applies everywhere.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	selector k8slabels.Selector
+}
+
+// ParseProtected parses the protected labels configuration.
+// Two formats are accepted: the legacy JSON object mapping protected keys to values,
+// and a JSON array of ProtectedRule entries that may carry a namespace selector.
+func ParseProtected(data []byte) ([]ProtectedRule, error) {
+	trimmed := strings.TrimSpace(string(data))
+
+	var rules []ProtectedRule
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &rules); err != nil {
+			return nil, fmt.Errorf("failed to parse protected label rules: %w", err)
+		}
+	} else {
+		legacy := make(map[string]string)
+		if err := json.Unmarshal([]byte(trimmed), &legacy); err != nil {
+			return nil, fmt.Errorf("failed to parse protected labels: %w", err)
+		}
+		for key := range legacy {
+			rules = append(rules, ProtectedRule{Key: key})
+		}
+	}
+
+	for i := range rules {
+		if rules[i].Key == "" {
+			return nil, fmt.Errorf("protected label rule %d has an empty key", i)
+		}
+		if rules[i].NamespaceSelector == nil {
+			rules[i].selector = k8slabels.Everything()
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(rules[i].NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("protected label rule %q has an invalid namespace selector: %w", rules[i].Key, err)
+		}
+		rules[i].selector = selector
+	}
+	return rules, nil
+}
+
+// LoadProtected loads a set of "protected" label rules from an environment variable.
+// It returns ErrProtectedNotSet when the variable is not set, and the parse error when it is malformed.
+func LoadProtected(logger logr.Logger) ([]ProtectedRule, error) {
 	protectedLabelsJSON := os.Getenv(ProtectedLabelsEnv)
 	if protectedLabelsJSON == "" {
-		return nil, fmt.Errorf("PROTECTED_LABELS environment variable is not set")
+		return nil, ErrProtectedNotSet
 	}
 
-	protectedLabels := make(map[string]string)
-	if err := json.Unmarshal([]byte(protectedLabelsJSON), &protectedLabels); err != nil {
+	rules, err := ParseProtected([]byte(protectedLabelsJSON))
+	if err != nil {
 		logger.Error(err, "failed to parse PROTECTED_LABELS")
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// LoadProtectedFromConfigMap loads the protected label rules from the ProtectedConfigMapDataKey of the given
+// ConfigMap. Unlike LoadProtected, it is read on every call, so the protected set can change without a restart.
+// A missing ConfigMap, a missing data key and malformed JSON are all errors: the caller must not treat them as an
+// empty protected set.
+func LoadProtectedFromConfigMap(ctx context.Context, reader client.Reader, key types.NamespacedName) ([]ProtectedRule, error) {
+	var configMap corev1.ConfigMap
+	if err := reader.Get(ctx, key, &configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrProtectedConfigMapNotFound, key)
+		}
+		return nil, fmt.Errorf("failed to get protected labels ConfigMap %s: %w", key, err)
+	}
+
+	data, ok := configMap.Data[ProtectedConfigMapDataKey]
+	if !ok {
+		return nil, fmt.Errorf("protected labels ConfigMap %s has no %s key", key, ProtectedConfigMapDataKey)
+	}
+	rules, err := ParseProtected([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("protected labels ConfigMap %s: %w", key, err)
+	}
+	return rules, nil
+}
+
+// LoadProtectedAnnotations loads the protected annotation rules from ProtectedAnnotationsEnv. It returns no rules
+// when the variable is not set, and the parse error when it is malformed.
+func LoadProtectedAnnotations(logger logr.Logger) ([]ProtectedRule, error) {
+	protectedAnnotationsJSON := os.Getenv(ProtectedAnnotationsEnv)
+	if protectedAnnotationsJSON == "" {
+		return nil, nil
+	}
+
+	rules, err := ParseProtected([]byte(protectedAnnotationsJSON))
+	if err != nil {
+		logger.Error(err, "failed to parse PROTECTED_ANNOTATIONS")
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// IsProtected reports whether the key is protected in the given namespace.
+func IsProtected(rules []ProtectedRule, namespace *corev1.Namespace, key string) bool {
+	for _, rule := range rules {
+		if !rule.matchesKey(key) {
+			continue
+		}
+		if rule.selector == nil || rule.selector.Matches(k8slabels.Set(namespace.Labels)) {
+			return true
+		}
 	}
+	return false
+}
+
+// matchesKey reports whether the rule's key, or its prefix when it ends with "*", matches the given key.
+func (r ProtectedRule) matchesKey(key string) bool {
+	if prefix, ok := strings.CutSuffix(r.Key, "*"); ok {
+		return strings.HasPrefix(key, prefix)
+	}
+	return r.Key == key
+}
+
+// ExcludedKeys returns the label keys excluded by the namespace's ExcludeAnnotation.
+func ExcludedKeys(namespace *corev1.Namespace) map[string]bool {
+	return keySet(namespace.Annotations[ExcludeAnnotation])
+}
+
+// YieldKeys returns the label keys listed in YieldKeysEnv.
+func YieldKeys() map[string]bool {
+	return keySet(os.Getenv(YieldKeysEnv))
+}
+
+// ValuesEqual reports whether two label values are the same under the semantics set by CaseInsensitiveValuesEnv.
+func ValuesEqual(a, b string) bool {
+	if os.Getenv(CaseInsensitiveValuesEnv) == "true" {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// DeprecatedKeys returns the label keys listed in DeprecatedKeysEnv.
+func DeprecatedKeys() map[string]bool {
+	return keySet(os.Getenv(DeprecatedKeysEnv))
+}
+
+// RedactedValue replaces label values in the status of a Namespacelabel that sets spec.redactStatusValues.
+const RedactedValue = "<redacted>"
+
+// Redact returns the labels with every value replaced by RedactedValue.
+func Redact(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(labels))
+	for key := range labels {
+		redacted[key] = RedactedValue
+	}
+	return redacted
+}
+
+// keySet returns the keys in a comma-separated list.
+func keySet(list string) map[string]bool {
+	keys := make(map[string]bool)
+	for _, key := range strings.Split(list, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// MirrorAnnotationKey returns the namespace annotation key that mirrors the label key when
+// spec.mirrorToAnnotations is set. A "/" in the label key is replaced with "_", since an annotation key
+// may only carry one prefix. When the resulting name would exceed the 63 character limit, it is cut short and ends
+// with a hash of the label key instead, so long keys still map to distinct, valid annotation keys.
+func MirrorAnnotationKey(key string) string {
+	name := strings.TrimPrefix(MirrorAnnotationPrefix, ReservedPrefix) + strings.ReplaceAll(key, "/", "_")
+	if len(name) > qualifiedNameMaxLength {
+		sum := sha256.Sum256([]byte(key))
+		suffix := "-" + hex.EncodeToString(sum[:])[:specHashLength]
+		name = name[:qualifiedNameMaxLength-len(suffix)] + suffix
+	}
+	return ReservedPrefix + name
+}
 
-	return protectedLabels, nil
+// HasMirrors reports whether the namespace carries a mirror annotation for any of the given label keys.
+func HasMirrors(namespace *corev1.Namespace, keys map[string]string) bool {
+	for key := range keys {
+		if _, ok := namespace.Annotations[MirrorAnnotationKey(key)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// systemLabelKeys are namespace labels maintained by Kubernetes itself. A Namespacelabel setting one would fight
+// the system, so such keys are never applied, whether or not they are protected.
+var systemLabelKeys = map[string]bool{
+	corev1.LabelMetadataName: true,
+}
+
+// IsSystemLabel reports whether the key is a namespace label maintained by Kubernetes itself.
+func IsSystemLabel(key string) bool {
+	return systemLabelKeys[key]
+}
+
+// SpecHashLabelKey returns the spec hash label of the Namespacelabel with the given namespace and name. Each
+// Namespacelabel has its own, so Namespacelabels targeting the same namespace do not overwrite each other's hash.
+// The key ends with a short hash of "<namespace>/<name>", which keeps it within the label name length limit.
+func SpecHashLabelKey(namespace, name string) string {
+	sum := sha256.Sum256([]byte(namespace + "/" + name))
+	return SpecHashLabelPrefix + hex.EncodeToString(sum[:])[:specHashLength]
+}
+
+// SpecHash returns the short hash written to the spec hash label for the applied labels, or "" when there are none.
+func SpecHash(set map[string]string) string {
+	checksum := Checksum(set)
+	if len(checksum) > specHashLength {
+		checksum = checksum[:specHashLength]
+	}
+	return checksum
+}
+
+// Checksum returns a SHA-256 checksum of the labels that does not depend on map order, or "" when there are none.
+func Checksum(set map[string]string) string {
+	if len(set) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s=%s\n", key, set[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// OwnsApplyTimestamp reports whether the namespace's LastAppliedAnnotation still holds the stamp a Namespacelabel
+// recorded as written by it. The annotation is shared by the Namespacelabels targeting the namespace, so only its
+// last writer may remove it. An empty stamp is never owned.
+func OwnsApplyTimestamp(namespace *corev1.Namespace, stamp string) bool {
+	return stamp != "" && namespace.Annotations[LastAppliedAnnotation] == stamp
+}
+
+// IsOptedOut reports whether the namespace opted out of operator management with the OptOutAnnotation.
+func IsOptedOut(namespace *corev1.Namespace) bool {
+	return namespace.Annotations[OptOutAnnotation] == "true"
+}
+
+// WithAliases adds, for every key of desired that has aliases, each alias key with the same value.
+// Keys already in desired are left as they are.
+func WithAliases(desired map[string]string, aliases map[string][]string) map[string]string {
+	withAliases := make(map[string]string, len(desired))
+	for key, value := range desired {
+		withAliases[key] = value
+	}
+	for source, aliasKeys := range aliases {
+		value, ok := desired[source]
+		if !ok {
+			continue
+		}
+		for _, alias := range aliasKeys {
+			if _, exists := desired[alias]; !exists {
+				withAliases[alias] = value
+			}
+		}
+	}
+	return withAliases
 }
 
 // Cleanup modifies the namespace's labels based on the given label map.
@@ -36,6 +393,7 @@ func Cleanup(namespace *corev1.Namespace, labelsToRemove map[string]string, logg
 	for key := range labelsToRemove {
 		logger.Info("Removing label", "key", key)
 		delete(namespace.Labels, key)
+		delete(namespace.Annotations, MirrorAnnotationKey(key))
 	}
 
 	logger.Info("Label cleanup completed", "namespace", namespace.Name)
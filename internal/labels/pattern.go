@@ -0,0 +1,42 @@
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// CompileValuePattern compiles a spec.valuePatterns entry. The pattern must match the whole value,
+// so "[0-9]{4}" accepts "1234" but not "cc-1234".
+func CompileValuePattern(pattern string) (*regexp.Regexp, error) {
+	compiled, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid value pattern %q: %w", pattern, err)
+	}
+	return compiled, nil
+}
+
+// CheckValuePattern returns an error when the value does not match the pattern or the pattern does not compile.
+// An empty pattern accepts every value.
+func CheckValuePattern(pattern, value string) error {
+	if pattern == "" {
+		return nil
+	}
+	compiled, err := CompileValuePattern(pattern)
+	if err != nil {
+		return err
+	}
+	if !compiled.MatchString(value) {
+		return fmt.Errorf("value %q does not match pattern %q", value, pattern)
+	}
+	return nil
+}
+
+// CheckValueEnum returns an error when the value is not one of the allowed values of a spec.valueEnums entry.
+func CheckValueEnum(allowed []string, value string) error {
+	if !slices.Contains(allowed, value) {
+		return fmt.Errorf("value %q is not one of %s", value, strings.Join(allowed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package labels
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+)
+
+// ProtectedIndex answers IsProtected for many keys without scanning every rule for each of them.
+// Build it once per reconcile with NewProtectedIndex.
+type ProtectedIndex struct {
+	// exact holds the rules protecting a single key, by key.
+	exact map[string][]ProtectedRule
+	// prefixed holds the wildcard rules by the prefix they protect, and prefixLengths the distinct lengths of
+	// those prefixes in ascending order, so a key is matched with one map lookup per length.
+	prefixed      map[string][]ProtectedRule
+	prefixLengths []int
+}
+
+// NewProtectedIndex indexes the rules by exact key and by wildcard prefix.
+func NewProtectedIndex(rules []ProtectedRule) *ProtectedIndex {
+	index := &ProtectedIndex{
+		exact:    make(map[string][]ProtectedRule),
+		prefixed: make(map[string][]ProtectedRule),
+	}
+	lengths := make(map[int]bool)
+	for _, rule := range rules {
+		if prefix, ok := strings.CutSuffix(rule.Key, "*"); ok {
+			index.prefixed[prefix] = append(index.prefixed[prefix], rule)
+			lengths[len(prefix)] = true
+			continue
+		}
+		index.exact[rule.Key] = append(index.exact[rule.Key], rule)
+	}
+	for length := range lengths {
+		index.prefixLengths = append(index.prefixLengths, length)
+	}
+	sort.Ints(index.prefixLengths)
+	return index
+}
+
+// IsProtected reports whether the key is protected in the given namespace. It agrees with the package-level
+// IsProtected for the rules the index was built from.
+func (i *ProtectedIndex) IsProtected(namespace *corev1.Namespace, key string) bool {
+	namespaceLabels := k8slabels.Set(namespace.Labels)
+	if anyRuleApplies(i.exact[key], namespaceLabels) {
+		return true
+	}
+	for _, length := range i.prefixLengths {
+		if length > len(key) {
+			break
+		}
+		if anyRuleApplies(i.prefixed[key[:length]], namespaceLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyRuleApplies reports whether any of the rules applies to a namespace with the given labels.
+func anyRuleApplies(rules []ProtectedRule, namespaceLabels k8slabels.Set) bool {
+	for _, rule := range rules {
+		if rule.selector == nil || rule.selector.Matches(namespaceLabels) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package labels
+
+import (
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// benchmarkInputs returns a protected configuration with exact and wildcard rules, a namespace carrying many labels,
+// and the desired keys a large Namespacelabel would check.
+func benchmarkInputs(b *testing.B) ([]ProtectedRule, *corev1.Namespace, []string) {
+	b.Helper()
+
+	var config string
+	for i := 0; i < 500; i++ {
+		if config != "" {
+			config += ","
+		}
+		config += fmt.Sprintf(`{"key":"protected.example.com/key-%d"},{"key":"team-%d.example.com/*"}`, i, i)
+	}
+	rules, err := ParseProtected([]byte("[" + config + "]"))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bench", Labels: make(map[string]string)}}
+	keys := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		namespace.Labels[fmt.Sprintf("existing-%d", i)] = "value"
+		keys = append(keys, fmt.Sprintf("app.example.com/key-%d", i))
+	}
+	return rules, namespace, keys
+}
+
+func BenchmarkIsProtected(b *testing.B) {
+	rules, namespace, keys := benchmarkInputs(b)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, key := range keys {
+			IsProtected(rules, namespace, key)
+		}
+	}
+}
+
+func BenchmarkProtectedIndex(b *testing.B) {
+	rules, namespace, keys := benchmarkInputs(b)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		index := NewProtectedIndex(rules)
+		for _, key := range keys {
+			index.IsProtected(namespace, key)
+		}
+	}
+}
+
+func TestProtectedIndexAgreesWithIsProtected(t *testing.T) {
+	rules, err := ParseProtected([]byte(`[
+		{"key":"team"},
+		{"key":"example.com/*"},
+		{"key":"env","namespaceSelector":{"matchLabels":{"tier":"prod"}}}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := NewProtectedIndex(rules)
+
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"tier": "prod"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dev"}},
+	}
+	for _, namespace := range namespaces {
+		for _, key := range []string{"team", "teams", "example.com/owner", "example.co", "env", "app"} {
+			if got, want := index.IsProtected(namespace, key), IsProtected(rules, namespace, key); got != want {
+				t.Errorf("namespace %s, key %q: index says %v, IsProtected says %v", namespace.Name, key, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package labels
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StickyAnnotation is the namespace annotation holding, as a JSON object, the labels deleted Namespacelabels left in
+// place in sticky mode, keyed by the "namespace/name" identity of each of them. A Namespacelabel recreated with the
+// same identity before its entry expires adopts the labels instead of applying them again.
+const StickyAnnotation = "labels.dana.io/sticky"
+
+// StickyRecord is one entry of the StickyAnnotation.
+type StickyRecord struct {
+	// Labels are the labels the deleted Namespacelabel had applied, with their values on the namespace.
+	Labels map[string]string `json:"labels"`
+	// Expires is when the grace period ends. The labels are removed then, unless they were adopted.
+	Expires metav1.Time `json:"expires"`
+}
+
+// StickyRecords returns the entries of the namespace's StickyAnnotation. It returns an empty map when the namespace
+// has none, and the parse error when the annotation is malformed.
+func StickyRecords(namespace *corev1.Namespace) (map[string]StickyRecord, error) {
+	records := make(map[string]StickyRecord)
+	data, ok := namespace.Annotations[StickyAnnotation]
+	if !ok {
+		return records, nil
+	}
+	if err := json.Unmarshal([]byte(data), &records); err != nil {
+		return make(map[string]StickyRecord), fmt.Errorf("failed to parse the %s annotation: %w", StickyAnnotation, err)
+	}
+	return records, nil
+}
+
+// SetStickyRecords writes the entries to the namespace's StickyAnnotation, dropping the annotation when there are none.
+func SetStickyRecords(namespace *corev1.Namespace, records map[string]StickyRecord) error {
+	if len(records) == 0 {
+		delete(namespace.Annotations, StickyAnnotation)
+		return nil
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to encode the %s annotation: %w", StickyAnnotation, err)
+	}
+	if namespace.Annotations == nil {
+		namespace.Annotations = make(map[string]string)
+	}
+	namespace.Annotations[StickyAnnotation] = string(data)
+	return nil
+}
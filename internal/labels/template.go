@@ -0,0 +1,45 @@
+package labels
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// templateData is what a label value template is executed against.
+type templateData struct {
+	// Vars holds the cluster-wide template variables, so `{{ .Vars.region }}` reads the "region" variable.
+	Vars map[string]string
+}
+
+// CheckTemplate returns an error when the label value contains template syntax that does not parse.
+func CheckTemplate(value string) error {
+	_, err := parseTemplate(value)
+	return err
+}
+
+// Render executes the label value as a template with the given variables. Values without template syntax are
+// returned unchanged. Referencing a variable that is not set is an error, rather than rendering an empty value.
+func Render(value string, vars map[string]string) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+	tmpl, err := parseTemplate(value)
+	if err != nil {
+		return "", err
+	}
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, templateData{Vars: vars}); err != nil {
+		return "", fmt.Errorf("failed to render template %q: %w", value, err)
+	}
+	return rendered.String(), nil
+}
+
+// parseTemplate parses a label value template.
+func parseTemplate(value string) (*template.Template, error) {
+	tmpl, err := template.New("value").Option("missingkey=error").Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %q: %w", value, err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,59 @@
+package labels
+
+import (
+	"fmt"
+	"strings"
+
+	labelsv1alpha1 "github.com/matanamar10/namespacelabel-operator/api/v1alpha1"
+)
+
+// Transform returns the value with the named transform applied.
+// It returns an error for a transform it does not know.
+func Transform(transform labelsv1alpha1.LabelTransform, value string) (string, error) {
+	switch transform {
+	case labelsv1alpha1.TransformUpper:
+		return strings.ToUpper(value), nil
+	case labelsv1alpha1.TransformLower:
+		return strings.ToLower(value), nil
+	case labelsv1alpha1.TransformTrim:
+		return strings.TrimSpace(value), nil
+	case labelsv1alpha1.TransformSlug:
+		return slug(value), nil
+	default:
+		return "", fmt.Errorf("unknown transform %q; supported transforms are upper, lower, trim and slug", transform)
+	}
+}
+
+// WithTransforms returns a copy of desired with each key's transform applied to its value.
+// Keys whose transform is unknown keep their value; the webhook rejects unknown transforms at admission.
+func WithTransforms(desired map[string]string, transforms map[string]labelsv1alpha1.LabelTransform) map[string]string {
+	transformed := make(map[string]string, len(desired))
+	for key, value := range desired {
+		transformed[key] = value
+		if transform, ok := transforms[key]; ok {
+			if result, err := Transform(transform, value); err == nil {
+				transformed[key] = result
+			}
+		}
+	}
+	return transformed
+}
+
+// slug lower-cases the value, collapses every run of characters other than ASCII letters and digits into a single "-",
+// and trims leading and trailing dashes.
+func slug(value string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// ManagedLabels reports how many labels each Namespacelabel currently manages on its target namespace.
+// Series are keyed by the target namespace and the Namespacelabel, as "<namespace>/<name>", so Namespacelabels
+// sharing a target do not overwrite each other; sum by namespace for a per-namespace total. A series is deleted
+// once its Namespacelabel's labels are cleaned up, so the number of series stays bounded by the Namespacelabels.
+var ManagedLabels = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "namespacelabel_managed_labels",
+		Help: "Number of labels currently managed by a Namespacelabel on a namespace.",
+	},
+	[]string{"namespace", "namespacelabel"},
+)
+
+// DroppedEvents counts the events not recorded because their object exceeded the per-object event rate limit.
+var DroppedEvents = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "namespacelabel_dropped_events_total",
+		Help: "Number of events dropped by the per-object event rate limit.",
+	},
+	[]string{"namespace"},
+)
+
+// AppliedLabels counts the labels added to or changed on a namespace, per target namespace. Labels that already
+// carry their value are not counted, so resyncs leave it alone.
+var AppliedLabels = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "namespacelabel_applied_total",
+		Help: "Number of labels added to or changed on a namespace.",
+	},
+	[]string{"namespace"},
+)
+
+// SkippedLabels counts the labels skipped for any reason, such as being protected, per target namespace. Skips are
+// counted on every reconcile that applies its plan, so the counter grows with resyncs; alert on its rate.
+var SkippedLabels = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "namespacelabel_skipped_total",
+		Help: "Number of labels skipped, for example because they are protected.",
+	},
+	[]string{"namespace"},
+)
+
+// DuplicateLabels counts the labels skipped because the namespace already carried them from someone else, per
+// target namespace. Like SkippedLabels, it grows with resyncs.
+var DuplicateLabels = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "namespacelabel_duplicate_total",
+		Help: "Number of labels skipped because the namespace already had them.",
+	},
+	[]string{"namespace"},
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(ManagedLabels, DroppedEvents, AppliedLabels, SkippedLabels, DuplicateLabels)
+}
+
+// SetManagedLabels records the number of labels the Namespacelabel manages on the namespace.
+func SetManagedLabels(namespace, namespaceLabel string, count int) {
+	ManagedLabels.WithLabelValues(namespace, namespaceLabel).Set(float64(count))
+}
+
+// ResetManagedLabels drops the Namespacelabel's series for the namespace once its managed labels are gone.
+func ResetManagedLabels(namespace, namespaceLabel string) {
+	ManagedLabels.DeleteLabelValues(namespace, namespaceLabel)
+}
+
+// RecordPlan counts the changed, skipped and duplicate labels of one label plan applied to the namespace.
+func RecordPlan(namespace string, applied, skipped, duplicates int) {
+	AppliedLabels.WithLabelValues(namespace).Add(float64(applied))
+	SkippedLabels.WithLabelValues(namespace).Add(float64(skipped))
+	DuplicateLabels.WithLabelValues(namespace).Add(float64(duplicates))
+}
+
+// IncDroppedEvents counts an event dropped for an object in the namespace.
+func IncDroppedEvents(namespace string) {
+	DroppedEvents.WithLabelValues(namespace).Inc()
+}
@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// WebhookURLEnv names the environment variable holding the URL that receives a JSON notification after each
+// apply that wrote the namespace and after each cleanup.
+const WebhookURLEnv = "NOTIFY_WEBHOOK_URL"
+
+// Notification events.
+const (
+	// EventApplied is sent after labels were written to a namespace.
+	EventApplied = "Applied"
+	// EventCleanedUp is sent after a deleted Namespacelabel's labels were removed.
+	EventCleanedUp = "CleanedUp"
+)
+
+const (
+	defaultTimeout  = 5 * time.Second
+	defaultAttempts = 3
+	defaultBackoff  = time.Second
+)
+
+// Notification is the JSON body posted to the notification webhook.
+type Notification struct {
+	Event          string            `json:"event"`
+	Namespacelabel string            `json:"namespacelabel"`
+	Namespace      string            `json:"namespace"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	Time           time.Time         `json:"time"`
+}
+
+// Notifier posts notifications to a webhook URL.
+type Notifier struct {
+	URL    string
+	Client *http.Client
+	// Timeout bounds each delivery attempt.
+	Timeout time.Duration
+	// Attempts is how many times a notification is posted before it is dropped.
+	Attempts int
+	// Backoff is the wait before the second attempt; each later attempt waits one Backoff longer.
+	Backoff time.Duration
+	Logger  logr.Logger
+}
+
+// NewNotifier returns a Notifier posting to url with the default timeout, attempts and backoff.
+func NewNotifier(url string, logger logr.Logger) *Notifier {
+	return &Notifier{
+		URL:      url,
+		Client:   http.DefaultClient,
+		Timeout:  defaultTimeout,
+		Attempts: defaultAttempts,
+		Backoff:  defaultBackoff,
+		Logger:   logger,
+	}
+}
+
+// Notify delivers the notification in the background, so a slow or failing endpoint never holds up a reconcile.
+// Delivery failures are logged. Notify on a nil Notifier does nothing.
+func (n *Notifier) Notify(notification Notification) {
+	if n == nil {
+		return
+	}
+	go func() {
+		if err := n.Send(context.Background(), notification); err != nil {
+			n.Logger.Error(err, "Failed to deliver notification", "event", notification.Event, "namespacelabel", notification.Namespacelabel)
+		}
+	}()
+}
+
+// Send posts the notification, retrying failed attempts, and returns the last error once every attempt failed.
+func (n *Notifier) Send(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= n.Attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt-1) * n.Backoff):
+			}
+		}
+		if lastErr = n.post(ctx, body); lastErr == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("notification not delivered after %d attempts: %w", n.Attempts, lastErr)
+}
+
+// post makes a single delivery attempt.
+func (n *Notifier) post(ctx context.Context, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, n.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post notification: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification endpoint returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package propagation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ParseTarget parses a propagation target into a GroupVersionResource.
+// Targets have the form "<version>/<resource>" for the core group, or "<group>/<version>/<resource>" otherwise,
+// for example "v1/configmaps" or "apps/v1/deployments".
+func ParseTarget(target string) (schema.GroupVersionResource, error) {
+	parts := strings.Split(target, "/")
+	for _, part := range parts {
+		if part == "" {
+			return schema.GroupVersionResource{}, fmt.Errorf("invalid propagation target %q: empty segment", target)
+		}
+	}
+
+	switch len(parts) {
+	case 2:
+		return schema.GroupVersionResource{Version: parts[0], Resource: parts[1]}, nil
+	case 3:
+		return schema.GroupVersionResource{Group: parts[0], Version: parts[1], Resource: parts[2]}, nil
+	default:
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid propagation target %q: expected <version>/<resource> or <group>/<version>/<resource>", target)
+	}
+}
+
+// SupportedTargets lists the resources labels can be propagated to. The operator's role grants list and patch on
+// exactly these, so the kubebuilder:rbac markers on the reconciler and config/rbac/role.yaml change along with it.
+// All of them are namespaced, so a target never reaches objects outside the Namespacelabel's namespace.
+var SupportedTargets = []schema.GroupResource{
+	{Resource: "configmaps"},
+	{Resource: "serviceaccounts"},
+	{Resource: "services"},
+	{Group: "apps", Resource: "daemonsets"},
+	{Group: "apps", Resource: "deployments"},
+	{Group: "apps", Resource: "statefulsets"},
+}
+
+// ErrUnsupportedTarget is returned for a target naming a resource missing from SupportedTargets.
+var ErrUnsupportedTarget = errors.New("unsupported propagation target")
+
+// ValidateTarget parses a propagation target and returns an error wrapping ErrUnsupportedTarget when its resource is
+// not one of SupportedTargets.
+func ValidateTarget(target string) (schema.GroupVersionResource, error) {
+	gvr, err := ParseTarget(target)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	names := make([]string, 0, len(SupportedTargets))
+	for _, supported := range SupportedTargets {
+		if gvr.GroupResource() == supported {
+			return gvr, nil
+		}
+		names = append(names, supported.String())
+	}
+	return schema.GroupVersionResource{}, fmt.Errorf("%w %q: supported resources are %s", ErrUnsupportedTarget, target, strings.Join(names, ", "))
+}
+
+// Resolve returns the REST mapping of a propagation target, and an error when it cannot be parsed or mapped or when
+// it is not one of SupportedTargets.
+func Resolve(mapper meta.RESTMapper, target string) (*meta.RESTMapping, error) {
+	gvr, err := ValidateTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := mapper.KindFor(gvr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve kind for %q: %w", target, err)
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mapping for %q: %w", target, err)
+	}
+	return mapping, nil
+}
+
+// Apply sets the given labels on every object of the target resource types in the namespace.
+func Apply(ctx context.Context, c client.Client, namespace string, targets []string, labelsToApply map[string]string, fieldManager string, logger logr.Logger) error {
+	return forEachObject(ctx, c, namespace, targets, fieldManager, logger, func(obj *unstructured.Unstructured) bool {
+		objLabels := obj.GetLabels()
+		if objLabels == nil {
+			objLabels = make(map[string]string)
+		}
+
+		changed := false
+		for key, value := range labelsToApply {
+			if current, ok := objLabels[key]; !ok || current != value {
+				objLabels[key] = value
+				changed = true
+			}
+		}
+		obj.SetLabels(objLabels)
+		return changed
+	})
+}
+
+// Cleanup removes the given labels from every object of the target resource types in the namespace.
+// A label is only removed when its value still matches, so values changed by someone else are left alone.
+func Cleanup(ctx context.Context, c client.Client, namespace string, targets []string, labelsToRemove map[string]string, fieldManager string, logger logr.Logger) error {
+	return forEachObject(ctx, c, namespace, targets, fieldManager, logger, func(obj *unstructured.Unstructured) bool {
+		objLabels := obj.GetLabels()
+
+		changed := false
+		for key, value := range labelsToRemove {
+			if current, ok := objLabels[key]; ok && current == value {
+				delete(objLabels, key)
+				changed = true
+			}
+		}
+		obj.SetLabels(objLabels)
+		return changed
+	})
+}
+
+// forEachObject lists the objects of every target resource type in the namespace and patches those that mutate changed.
+func forEachObject(ctx context.Context, c client.Client, namespace string, targets []string, fieldManager string, logger logr.Logger, mutate func(*unstructured.Unstructured) bool) error {
+	for _, target := range targets {
+		mapping, err := Resolve(c.RESTMapper(), target)
+		if errors.Is(err, ErrUnsupportedTarget) {
+			// The webhook rejects these; a Namespacelabel admitted before it did would only fail on missing permissions.
+			logger.Info("Skipping unsupported propagation target", "target", target)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		gvk := mapping.GroupVersionKind
+
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return fmt.Errorf("failed to list %q in namespace %s: %w", target, namespace, err)
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			original := obj.DeepCopy()
+			if !mutate(obj) {
+				continue
+			}
+			if err := c.Patch(ctx, obj, client.MergeFrom(original), client.FieldOwner(fieldManager)); err != nil {
+				return fmt.Errorf("failed to patch %s %s/%s: %w", gvk.Kind, namespace, obj.GetName(), err)
+			}
+			logger.Info("Propagated labels updated", "kind", gvk.Kind, "namespace", namespace, "name", obj.GetName())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package throttle
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NamespaceWriteQPSEnv names the environment variable capping how many namespace updates and patches per second
+// the operator sends, across all reconciles. Writes over the limit wait for their turn. Unset, writes are not limited.
+const NamespaceWriteQPSEnv = "NAMESPACE_WRITE_QPS"
+
+// NamespaceWriteBurstEnv names the environment variable setting how many namespace writes may be sent back to back
+// before NamespaceWriteQPSEnv applies. Defaults to 1.
+const NamespaceWriteBurstEnv = "NAMESPACE_WRITE_BURST"
+
+// namespaceWriteLimitedClient waits on a shared token bucket before every namespace Update or Patch, so mass
+// reconciles cannot exhaust the API server. It is separate from the workqueue's rate limiter, which paces
+// reconciles rather than writes. Other calls pass straight through.
+type namespaceWriteLimitedClient struct {
+	client.Client
+	limiter *rate.Limiter
+}
+
+// NewNamespaceWriteLimitedClient returns a client sending at most qps namespace writes per second through c,
+// allowing bursts of up to burst writes.
+func NewNamespaceWriteLimitedClient(c client.Client, qps float64, burst int) client.Client {
+	return &namespaceWriteLimitedClient{
+		Client:  c,
+		limiter: rate.NewLimiter(rate.Limit(qps), burst),
+	}
+}
+
+func (c *namespaceWriteLimitedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.wait(ctx, obj); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *namespaceWriteLimitedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.wait(ctx, obj); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// wait blocks until the limiter admits a write to obj when it is a namespace.
+func (c *namespaceWriteLimitedClient) wait(ctx context.Context, obj client.Object) error {
+	if _, ok := obj.(*corev1.Namespace); !ok {
+		return nil
+	}
+	if err := c.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("namespace write throttled: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package vars
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapEnv names the environment variable holding the "namespace/name" of the ConfigMap whose data supplies the
+// variables label value templates read as `{{ .Vars.<key> }}`. The ConfigMap is read on every use, so changes take
+// effect without a restart.
+const ConfigMapEnv = "TEMPLATE_VARS_CONFIGMAP"
+
+// ConfigMapKey returns the configured variables ConfigMap, and false when none is configured.
+func ConfigMapKey() (types.NamespacedName, bool, error) {
+	value := os.Getenv(ConfigMapEnv)
+	if value == "" {
+		return types.NamespacedName{}, false, nil
+	}
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, false, fmt.Errorf("%s must be namespace/name, got %q", ConfigMapEnv, value)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, true, nil
+}
+
+// Load returns the template variables. It returns an empty set when no variables ConfigMap is configured or it does
+// not exist yet.
+func Load(ctx context.Context, reader client.Reader) (map[string]string, error) {
+	key, ok, err := ConfigMapKey()
+	if err != nil || !ok {
+		return map[string]string{}, err
+	}
+
+	var configMap corev1.ConfigMap
+	if err := reader.Get(ctx, key, &configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to get template variables ConfigMap %s: %w", key, err)
+	}
+	if configMap.Data == nil {
+		return map[string]string{}, nil
+	}
+	return configMap.Data, nil
+}
+
+// IsConfigMap reports whether the object is the configured variables ConfigMap.
+func IsConfigMap(object client.Object) bool {
+	key, ok, err := ConfigMapKey()
+	return err == nil && ok && key == client.ObjectKeyFromObject(object)
+}
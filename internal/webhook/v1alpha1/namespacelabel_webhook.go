@@ -19,18 +19,116 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	labelsv1alpha1 "github.com/matanamar10/namespacelabel-operator/api/v1alpha1"
+	"github.com/matanamar10/namespacelabel-operator/internal/denylist"
+	"github.com/matanamar10/namespacelabel-operator/internal/labels"
+	"github.com/matanamar10/namespacelabel-operator/internal/propagation"
+	"github.com/matanamar10/namespacelabel-operator/internal/window"
+)
+
+// ExemptProtectedGroupsEnv names the environment variable listing, comma-separated, the groups allowed to set the
+// labels.dana.io/exempt-protected annotation. Defaults to DefaultExemptProtectedGroups.
+const ExemptProtectedGroupsEnv = "EXEMPT_PROTECTED_GROUPS"
+
+// DefaultExemptProtectedGroups is the group allowed to exempt a Namespacelabel from protected labels by default.
+const DefaultExemptProtectedGroups = "system:masters"
+
+// LabelKeyConventionEnv names the environment variable selecting the convention every label key must follow.
+// With "prefixed", a key naming a domain must put it in the prefix, as in example.com/team, so team.example.com
+// is rejected. With "flat", keys may not have a prefix at all. Unset, any valid key is admitted.
+const LabelKeyConventionEnv = "LABEL_KEY_CONVENTION"
+
+// MaxTotalNamespacelabelsEnv names the environment variable capping how many Namespacelabels may exist across the
+// whole cluster, to protect small clusters. Creates beyond the cap are rejected. Unset or 0 means no cap.
+const MaxTotalNamespacelabelsEnv = "MAX_TOTAL_NAMESPACELABELS"
+
+// Label key conventions accepted in LabelKeyConventionEnv.
+const (
+	KeyConventionPrefixed = "prefixed"
+	KeyConventionFlat     = "flat"
+)
+
+// ValidateConfig returns an error naming the first environment variable configuring admission that is set to an
+// invalid value. cmd/main.go calls it at startup so that a typo stops the operator from starting instead of
+// rejecting every admission; should one still reach a validator, the check it configures is logged and skipped.
+func ValidateConfig() error {
+	if _, err := keyConvention(); err != nil {
+		return err
+	}
+	if _, err := totalCap(); err != nil {
+		return err
+	}
+	if _, err := valueEnumMode(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// keyConvention returns the convention set in LabelKeyConventionEnv, empty when it is unset.
+func keyConvention() (string, error) {
+	convention := os.Getenv(LabelKeyConventionEnv)
+	if convention != "" && convention != KeyConventionPrefixed && convention != KeyConventionFlat {
+		return "", fmt.Errorf("%s is %q; use %q or %q", LabelKeyConventionEnv, convention, KeyConventionPrefixed, KeyConventionFlat)
+	}
+	return convention, nil
+}
+
+// totalCap returns the cap set in MaxTotalNamespacelabelsEnv, zero when there is none.
+func totalCap() (int, error) {
+	capEnv := os.Getenv(MaxTotalNamespacelabelsEnv)
+	if capEnv == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(capEnv)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("%s is %q; use a non-negative integer", MaxTotalNamespacelabelsEnv, capEnv)
+	}
+	return limit, nil
+}
+
+// valueEnumMode returns the mode set in ValueEnumModeEnv, ValueEnumModeSkip when it is unset.
+func valueEnumMode() (string, error) {
+	switch mode := os.Getenv(ValueEnumModeEnv); mode {
+	case "", ValueEnumModeSkip:
+		return ValueEnumModeSkip, nil
+	case ValueEnumModeReject:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%s is %q; use %q or %q", ValueEnumModeEnv, mode, ValueEnumModeSkip, ValueEnumModeReject)
+	}
+}
+
+// ValueEnumModeEnv names the environment variable choosing how spec.valueEnums is enforced at admission time: skip
+// (the default) admits disallowed values and leaves the controller to skip them, reject refuses them.
+const ValueEnumModeEnv = "VALUE_ENUM_MODE"
+
+// Value enum modes accepted in ValueEnumModeEnv.
+const (
+	ValueEnumModeSkip   = "skip"
+	ValueEnumModeReject = "reject"
 )
 
 // nolint:unused
@@ -40,7 +138,11 @@ var namespacelabellog = logf.Log.WithName("namespacelabel-resource")
 // SetupNamespacelabelWebhookWithManager registers the webhook for Namespacelabel in the manager.
 func SetupNamespacelabelWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&labelsv1alpha1.Namespacelabel{}).
-		WithValidator(&NamespacelabelCustomValidator{}).
+		WithValidator(&NamespacelabelCustomValidator{
+			Client:   mgr.GetClient(),
+			Logger:   namespacelabellog,
+			Recorder: mgr.GetEventRecorderFor("NamespacelabelWebhook"),
+		}).
 		Complete()
 }
 
@@ -64,6 +166,25 @@ func (v *NamespacelabelCustomValidator) ValidateCreate(ctx context.Context, obj
 		return nil, fmt.Errorf("unexpected object type: %T", obj)
 	}
 
+	if err := validateSpec(namespaceLabel); err != nil {
+		return nil, err
+	}
+	if err := validateExemptProtected(ctx, nil, namespaceLabel); err != nil {
+		return nil, err
+	}
+	if err := v.validateNotDenied(ctx, namespaceLabel); err != nil {
+		return nil, err
+	}
+	if err := v.validateTargetAccess(ctx, namespaceLabel); err != nil {
+		return nil, err
+	}
+	if err := v.validateRequiresAccess(ctx, namespaceLabel); err != nil {
+		return nil, err
+	}
+	if err := v.validateTotalCap(ctx, namespaceLabel); err != nil {
+		return nil, err
+	}
+
 	existingnamespaceLabels := &labelsv1alpha1.NamespacelabelList{}
 	if err := v.Client.List(ctx, existingnamespaceLabels, client.InNamespace(namespaceLabel.Namespace)); err != nil {
 		return nil, fmt.Errorf("failed to list NamespaceLabels: %v", err)
@@ -76,7 +197,31 @@ func (v *NamespacelabelCustomValidator) ValidateCreate(ctx context.Context, obj
 		return nil, fmt.Errorf("only one NamespaceLabel is allowed per namespace; found %d existing", len(existingnamespaceLabels.Items))
 	}
 
-	return nil, nil
+	return v.noOpWarnings(ctx, namespaceLabel, nil), nil
+}
+
+// validateTotalCap rejects a create that would take the number of Namespacelabels across all namespaces past
+// MaxTotalNamespacelabelsEnv.
+func (v *NamespacelabelCustomValidator) validateTotalCap(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	limit, err := totalCap()
+	if err != nil {
+		v.Logger.Error(err, "Skipping the cluster-wide Namespacelabel cap")
+		return nil
+	}
+	if limit == 0 {
+		return nil
+	}
+
+	allNamespaceLabels := &labelsv1alpha1.NamespacelabelList{}
+	if err := v.Client.List(ctx, allNamespaceLabels); err != nil {
+		return fmt.Errorf("failed to list NamespaceLabels: %v", err)
+	}
+	if len(allNamespaceLabels.Items) >= limit {
+		v.Recorder.Eventf(namespaceLabel, corev1.EventTypeWarning, "FailedCreate",
+			"the cluster already has %d NamespaceLabels, the most %s allows", len(allNamespaceLabels.Items), MaxTotalNamespacelabelsEnv)
+		return fmt.Errorf("the cluster already has %d NamespaceLabels, the most %s allows", len(allNamespaceLabels.Items), MaxTotalNamespacelabelsEnv)
+	}
+	return nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Namespacelabel.
@@ -86,7 +231,78 @@ func (v *NamespacelabelCustomValidator) ValidateUpdate(ctx context.Context, oldO
 		return nil, fmt.Errorf("expected a Namespacelabel object for the newObj but got %T", newObj)
 	}
 	namespacelabellog.Info("Validation for Namespacelabel upon update", "name", namespacelabel.GetName())
-	return nil, nil
+
+	// Updates that leave the spec alone, such as the controller removing its finalizer, are not validated again:
+	// a namespace added to the deny ConfigMap or a tightened convention since must not leave the CR stuck.
+	oldNamespacelabel, hasOld := oldObj.(*labelsv1alpha1.Namespacelabel)
+	if !namespacelabel.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	if hasOld && equality.Semantic.DeepEqual(oldNamespacelabel.Spec, namespacelabel.Spec) {
+		return nil, validateExemptProtected(ctx, oldNamespacelabel, namespacelabel)
+	}
+
+	if err := validateSpec(namespacelabel); err != nil {
+		return nil, err
+	}
+	if err := v.validateNotDenied(ctx, namespacelabel); err != nil {
+		return nil, err
+	}
+	if err := v.validateTargetAccess(ctx, namespacelabel); err != nil {
+		return nil, err
+	}
+	if err := v.validateRequiresAccess(ctx, namespacelabel); err != nil {
+		return nil, err
+	}
+
+	var appliedLabels map[string]string
+	var warnings admission.Warnings
+	if hasOld {
+		if err := validateImmutableKeys(oldNamespacelabel, namespacelabel); err != nil {
+			return nil, err
+		}
+		if err := validateExemptProtected(ctx, oldNamespacelabel, namespacelabel); err != nil {
+			return nil, err
+		}
+		appliedLabels = oldNamespacelabel.Status.AppliedLabels
+		warnings = repeatSkipWarnings(oldNamespacelabel, namespacelabel)
+	}
+	return append(v.noOpWarnings(ctx, namespacelabel, appliedLabels), warnings...), nil
+}
+
+// repeatSkipWarnings warns about the labels the updated Namespacelabel still requests that the controller skipped on
+// its last apply, as recorded in the old object's status, since they will most likely be skipped again. Skipped labels
+// carry their reason in status.skipReasons. Duplicates are not listed in status, so while the DuplicateLabels condition
+// is True they are taken to be the spec labels that were neither applied nor skipped.
+func repeatSkipWarnings(oldNamespaceLabel, newNamespaceLabel *labelsv1alpha1.Namespacelabel) admission.Warnings {
+	status := oldNamespaceLabel.Status
+	duplicates := meta.IsStatusConditionTrue(status.Conditions, string(labelsv1alpha1.ConditionDuplicateLabels))
+
+	keys := make([]string, 0, len(newNamespaceLabel.Spec.Labels))
+	for key := range newNamespaceLabel.Spec.Labels {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var warnings admission.Warnings
+	for _, key := range keys {
+		value := newNamespaceLabel.Spec.Labels[key]
+		if skippedValue, skipped := status.SkippedLabels[key]; skipped {
+			// A value change may well get the label applied, unless the stored value is redacted and cannot tell.
+			if skippedValue != value && skippedValue != labels.RedactedValue {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("label %s was skipped on the last apply (%s) and will likely be skipped again",
+				key, status.SkipReasons[key]))
+			continue
+		}
+		_, applied := status.AppliedLabels[key]
+		_, requested := oldNamespaceLabel.Spec.Labels[key]
+		if duplicates && requested && !applied {
+			warnings = append(warnings, fmt.Sprintf("label %s was skipped on the last apply (duplicate of an existing namespace label) and will likely be skipped again", key))
+		}
+	}
+	return warnings
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Namespacelabel.
@@ -98,3 +314,472 @@ func (v *NamespacelabelCustomValidator) ValidateDelete(ctx context.Context, obj
 	namespacelabellog.Info("Validation for Namespacelabel upon deletion", "name", namespacelabel.GetName())
 	return nil, nil
 }
+
+// noOpWarnings warns when the Namespacelabel would do nothing because its namespace already carries every desired
+// label with the same value, compared the way the controller compares them. Labels the CR applied itself, listed in appliedLabels, are owned rather than duplicates.
+// The namespace is only read for the warning, so failing to read it admits the CR without one.
+func (v *NamespacelabelCustomValidator) noOpWarnings(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel, appliedLabels map[string]string) admission.Warnings {
+	if len(namespaceLabel.Spec.Labels) == 0 || namespaceLabel.Spec.NamespaceLabelSelector != nil {
+		return nil
+	}
+	targetName := namespaceLabel.Namespace
+	if namespaceLabel.Spec.NamespaceName != "" {
+		targetName = namespaceLabel.Spec.NamespaceName
+	}
+
+	var namespace corev1.Namespace
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: targetName}, &namespace); err != nil {
+		v.Logger.Error(err, "Failed to get namespace for no-op check", "namespace", targetName)
+		return nil
+	}
+
+	for key, value := range namespaceLabel.Spec.Labels {
+		if _, owned := appliedLabels[key]; owned {
+			return nil
+		}
+		if current, ok := namespace.Labels[key]; !ok || !labels.ValuesEqual(current, value) {
+			return nil
+		}
+	}
+	return admission.Warnings{fmt.Sprintf("Namespacelabel %s is a no-op: namespace %s already has all of its labels with the same values",
+		namespaceLabel.Name, targetName)}
+}
+
+// validateSpec runs the spec checks shared by create and update.
+func validateSpec(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	if err := validateNamespace(namespaceLabel); err != nil {
+		return err
+	}
+	if err := validateTemplateSyntax(namespaceLabel); err != nil {
+		return err
+	}
+	if err := validatePropagationTargets(namespaceLabel); err != nil {
+		return err
+	}
+	if err := validateTeamRef(namespaceLabel); err != nil {
+		return err
+	}
+	if err := validateReservedKeys(namespaceLabel); err != nil {
+		return err
+	}
+	if err := validateAnnotations(namespaceLabel); err != nil {
+		return err
+	}
+	if err := validateKeyConvention(namespaceLabel); err != nil {
+		return err
+	}
+	if err := validateTransforms(namespaceLabel); err != nil {
+		return err
+	}
+	if err := validateOverwritePolicy(namespaceLabel); err != nil {
+		return err
+	}
+	if err := validateValuePatterns(namespaceLabel); err != nil {
+		return err
+	}
+	if err := validateValueEnums(namespaceLabel); err != nil {
+		return err
+	}
+	if err := validateMaintenanceWindow(namespaceLabel); err != nil {
+		return err
+	}
+	return validateTarget(namespaceLabel)
+}
+
+// validateNamespace rejects a Namespacelabel without metadata.namespace. Namespacelabels are namespace-scoped, and the
+// controller falls back to the Namespacelabel's own namespace as its target.
+func validateNamespace(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	if namespaceLabel.Namespace == "" {
+		return fmt.Errorf("metadata.namespace must be set: Namespacelabel is a namespace-scoped resource")
+	}
+	return nil
+}
+
+// validateTemplateSyntax rejects label values that look like templates when templating is disabled, and templates
+// that do not parse when it is enabled. Without templating the braces would be applied literally, which is never a
+// valid label value.
+func validateTemplateSyntax(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	if namespaceLabel.Spec.EnableTemplating {
+		for key, value := range namespaceLabel.Spec.Labels {
+			if err := labels.CheckTemplate(value); err != nil {
+				return fmt.Errorf("label %q: %w", key, err)
+			}
+		}
+		return nil
+	}
+	for key, value := range namespaceLabel.Spec.Labels {
+		if strings.Contains(value, "{{") && strings.Contains(value, "}}") {
+			return fmt.Errorf("label %q has value %q which contains template syntax, but spec.enableTemplating is false; "+
+				"set spec.enableTemplating to true or remove the braces", key, value)
+		}
+	}
+	return nil
+}
+
+// validatePropagationTargets rejects propagation targets that cannot be parsed into a resource type or that name a
+// resource the operator is not allowed to patch.
+func validatePropagationTargets(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	for _, target := range namespaceLabel.Spec.PropagateTo {
+		if _, err := propagation.ValidateTarget(target); err != nil {
+			return fmt.Errorf("invalid spec.propagateTo entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateReservedKeys rejects label keys, including alias keys, under the operator's reserved prefix, which would
+// collide with the labels the operator manages itself.
+func validateReservedKeys(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	keys := make([]string, 0, len(namespaceLabel.Spec.Labels))
+	for key := range labels.WithAliases(namespaceLabel.Spec.Labels, namespaceLabel.Spec.Aliases) {
+		if labels.IsReserved(key) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	slices.Sort(keys)
+	return fmt.Errorf("label keys %s use the prefix %q, which is reserved for the operator", strings.Join(keys, ", "), labels.ReservedPrefix)
+}
+
+// validateAnnotations rejects spec.annotations keys that are not valid annotation keys, and keys under the operator's
+// reserved prefix, which would collide with the annotations the operator manages itself.
+func validateAnnotations(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	keys := make([]string, 0, len(namespaceLabel.Spec.Annotations))
+	for key := range namespaceLabel.Spec.Annotations {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var reserved []string
+	for _, key := range keys {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid spec.annotations key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if labels.IsReserved(key) {
+			reserved = append(reserved, key)
+		}
+	}
+	if len(reserved) == 0 {
+		return nil
+	}
+	return fmt.Errorf("annotation keys %s use the prefix %q, which is reserved for the operator", strings.Join(reserved, ", "), labels.ReservedPrefix)
+}
+
+// validateKeyConvention rejects label keys, including alias keys, that break the convention configured in
+// LabelKeyConventionEnv.
+func validateKeyConvention(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	convention, err := keyConvention()
+	if err != nil {
+		namespacelabellog.Error(err, "Skipping the label key convention check")
+		return nil
+	}
+	if convention == "" {
+		return nil
+	}
+
+	keys := make([]string, 0, len(namespaceLabel.Spec.Labels))
+	for key := range labels.WithAliases(namespaceLabel.Spec.Labels, namespaceLabel.Spec.Aliases) {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		_, name, prefixed := strings.Cut(key, "/")
+		switch {
+		case convention == KeyConventionFlat && prefixed:
+			return fmt.Errorf("label key %q has a prefix, but %s is %q; use %q", key, LabelKeyConventionEnv, convention, name)
+		case convention == KeyConventionPrefixed && !prefixed && strings.Contains(key, "."):
+			return fmt.Errorf("label key %q names a domain without a prefix, but %s is %q; put the domain in the prefix, as in %q",
+				key, LabelKeyConventionEnv, convention, prefixedForm(key))
+		}
+	}
+	return nil
+}
+
+// prefixedForm suggests the prefixed form of a dotted key, moving everything after the first dot into the prefix:
+// "team.example.com" becomes "example.com/team".
+func prefixedForm(key string) string {
+	name, domain, _ := strings.Cut(key, ".")
+	return domain + "/" + name
+}
+
+// validateTransforms rejects spec.transforms entries naming an unknown transform.
+func validateTransforms(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	for key, transform := range namespaceLabel.Spec.Transforms {
+		if _, err := labels.Transform(transform, ""); err != nil {
+			return fmt.Errorf("invalid spec.transforms entry for %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// validateOverwritePolicy rejects a spec.overwritePolicy other than Skip and Overwrite.
+func validateOverwritePolicy(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	switch namespaceLabel.Spec.OverwritePolicy {
+	case "", labelsv1alpha1.OverwritePolicySkip, labelsv1alpha1.OverwritePolicyOverwrite:
+		return nil
+	}
+	return fmt.Errorf("invalid spec.overwritePolicy %q: must be %s or %s", namespaceLabel.Spec.OverwritePolicy,
+		labelsv1alpha1.OverwritePolicySkip, labelsv1alpha1.OverwritePolicyOverwrite)
+}
+
+// validateValuePatterns rejects spec.valuePatterns entries that are not valid regular expressions.
+func validateValuePatterns(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	for key, pattern := range namespaceLabel.Spec.ValuePatterns {
+		if _, err := labels.CompileValuePattern(pattern); err != nil {
+			return fmt.Errorf("invalid spec.valuePatterns entry for %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// validateValueEnums rejects spec.valueEnums entries listing no values. When ValueEnumModeEnv is reject, it also
+// rejects label values, including alias values, that their entry does not allow. Templated values are left to the
+// controller, since they are only known once rendered. Only problems in the CR are returned, as field errors on
+// spec.valueEnums; an invalid ValueEnumModeEnv is logged and treated as skip.
+func validateValueEnums(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	path := field.NewPath("spec", "valueEnums")
+	for key, allowed := range namespaceLabel.Spec.ValueEnums {
+		if len(allowed) == 0 {
+			return field.Required(path.Key(key), "the entry lists no values")
+		}
+	}
+	mode, err := valueEnumMode()
+	if err != nil {
+		namespacelabellog.Error(err, "Skipping the value enum check")
+		return nil
+	}
+	if mode == ValueEnumModeSkip {
+		return nil
+	}
+
+	desired := labels.WithAliases(namespaceLabel.Spec.Labels, namespaceLabel.Spec.Aliases)
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		allowed, ok := namespaceLabel.Spec.ValueEnums[key]
+		value := desired[key]
+		if !ok || (namespaceLabel.Spec.EnableTemplating && strings.Contains(value, "{{")) {
+			continue
+		}
+		if transform, ok := namespaceLabel.Spec.Transforms[key]; ok {
+			// Unknown transforms were already rejected by validateTransforms.
+			value, _ = labels.Transform(transform, value)
+		}
+		if err := labels.CheckValueEnum(allowed, value); err != nil {
+			return field.Invalid(path.Key(key), value, fmt.Sprintf("label %q: %v", key, err))
+		}
+	}
+	return nil
+}
+
+// validateNotDenied rejects a Namespacelabel targeting a namespace listed in the deny ConfigMap.
+// A Namespacelabel targeting namespaces by selector is admitted; the controller refuses to label a denied match.
+func (v *NamespacelabelCustomValidator) validateNotDenied(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	if namespaceLabel.Spec.NamespaceLabelSelector != nil {
+		return nil
+	}
+	target := namespaceLabel.Namespace
+	if namespaceLabel.Spec.NamespaceName != "" {
+		target = namespaceLabel.Spec.NamespaceName
+	}
+
+	denied, err := denylist.Load(ctx, v.Client)
+	if err != nil {
+		return err
+	}
+	if denied[target] {
+		return fmt.Errorf("namespace %s is listed in the operator's deny ConfigMap and cannot be labeled", target)
+	}
+	return nil
+}
+
+// validateTargetAccess rejects a Namespacelabel targeting namespaces other than its own unless the requesting user
+// may patch them, so that spec.namespaceName and spec.namespaceLabelSelector cannot label namespaces the user has no
+// access to. A selector may match any namespace, so it requires patch access to all of them.
+func (v *NamespacelabelCustomValidator) validateTargetAccess(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	target := namespaceLabel.Spec.NamespaceName
+	if namespaceLabel.Spec.NamespaceLabelSelector == nil && (target == "" || target == namespaceLabel.Namespace) {
+		return nil
+	}
+
+	allowed, username, err := v.userMay(ctx, &authorizationv1.ResourceAttributes{
+		Verb:     "patch",
+		Resource: "namespaces",
+		Name:     target,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot verify access to the target namespace: %w", err)
+	}
+	if allowed {
+		return nil
+	}
+	if target == "" {
+		return fmt.Errorf("user %q may not patch namespaces, which spec.namespaceLabelSelector requires", username)
+	}
+	return fmt.Errorf("user %q may not patch namespace %s, which spec.namespaceName requires", username, target)
+}
+
+// validateRequiresAccess rejects a spec.requires naming a resource the requesting user may not get. The controller
+// looks the resource up with its own permissions and reports whether it exists in the CR's conditions, so without
+// this check any user could learn whether resources they cannot read exist.
+func (v *NamespacelabelCustomValidator) validateRequiresAccess(ctx context.Context, namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	requires := namespaceLabel.Spec.Requires
+	if requires == nil {
+		return nil
+	}
+
+	gv, err := schema.ParseGroupVersion(requires.APIVersion)
+	if err != nil {
+		return fmt.Errorf("invalid spec.requires.apiVersion %q: %w", requires.APIVersion, err)
+	}
+	mapping, err := v.Client.RESTMapper().RESTMapping(gv.WithKind(requires.Kind).GroupKind(), gv.Version)
+	if err != nil {
+		return fmt.Errorf("cannot verify access to spec.requires: failed to resolve %s %s: %w", requires.APIVersion, requires.Kind, err)
+	}
+	namespace := ""
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace = requires.Namespace
+		if namespace == "" {
+			namespace = namespaceLabel.Namespace
+		}
+	}
+
+	allowed, username, err := v.userMay(ctx, &authorizationv1.ResourceAttributes{
+		Verb:      "get",
+		Group:     mapping.Resource.Group,
+		Version:   mapping.Resource.Version,
+		Resource:  mapping.Resource.Resource,
+		Namespace: namespace,
+		Name:      requires.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot verify access to spec.requires: %w", err)
+	}
+	if !allowed {
+		return fmt.Errorf("user %q may not get %s %s, which spec.requires names", username, requires.Kind, requires.Name)
+	}
+	return nil
+}
+
+// userMay reports whether the user making the admission request in ctx may perform the action described by
+// attributes, along with that user's name, by creating a SubjectAccessReview.
+func (v *NamespacelabelCustomValidator) userMay(ctx context.Context, attributes *authorizationv1.ResourceAttributes) (bool, string, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return false, "", err
+	}
+	extra := make(map[string]authorizationv1.ExtraValue, len(req.UserInfo.Extra))
+	for key, values := range req.UserInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(values)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               req.UserInfo.Username,
+			UID:                req.UserInfo.UID,
+			Groups:             req.UserInfo.Groups,
+			Extra:              extra,
+			ResourceAttributes: attributes,
+		},
+	}
+	if err := v.Client.Create(ctx, review); err != nil {
+		return false, "", fmt.Errorf("failed to create SubjectAccessReview: %w", err)
+	}
+	return review.Status.Allowed, req.UserInfo.Username, nil
+}
+
+// validateMaintenanceWindow rejects a spec.maintenanceWindow whose times, days or time zone cannot be parsed.
+func validateMaintenanceWindow(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	if namespaceLabel.Spec.MaintenanceWindow == nil {
+		return nil
+	}
+	if _, err := window.Parse(namespaceLabel.Spec.MaintenanceWindow); err != nil {
+		return fmt.Errorf("invalid spec.maintenanceWindow: %w", err)
+	}
+	return nil
+}
+
+// validateTeamRef rejects a spec.teamRef that cannot identify a team object, or whose labelKey is not a valid label
+// key or uses the prefix reserved for the operator.
+func validateTeamRef(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	teamRef := namespaceLabel.Spec.TeamRef
+	if teamRef == nil {
+		return nil
+	}
+	if _, err := schema.ParseGroupVersion(teamRef.APIVersion); err != nil || teamRef.APIVersion == "" {
+		return fmt.Errorf("invalid spec.teamRef.apiVersion %q", teamRef.APIVersion)
+	}
+	if teamRef.Kind == "" || teamRef.Name == "" {
+		return fmt.Errorf("spec.teamRef must set both kind and name")
+	}
+	if teamRef.LabelKey != "" {
+		if errs := validation.IsQualifiedName(teamRef.LabelKey); len(errs) > 0 {
+			return fmt.Errorf("invalid spec.teamRef.labelKey %q: %s", teamRef.LabelKey, strings.Join(errs, "; "))
+		}
+		if labels.IsReserved(teamRef.LabelKey) {
+			return fmt.Errorf("spec.teamRef.labelKey %q uses the prefix %q, which is reserved for the operator", teamRef.LabelKey, labels.ReservedPrefix)
+		}
+	}
+	return nil
+}
+
+// validateTarget rejects a spec that sets both spec.namespaceName and spec.namespaceLabelSelector,
+// or whose selector cannot be parsed.
+func validateTarget(namespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	if namespaceLabel.Spec.NamespaceLabelSelector == nil {
+		return nil
+	}
+	if namespaceLabel.Spec.NamespaceName != "" {
+		return fmt.Errorf("spec.namespaceName and spec.namespaceLabelSelector cannot both be set")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(namespaceLabel.Spec.NamespaceLabelSelector); err != nil {
+		return fmt.Errorf("invalid spec.namespaceLabelSelector: %w", err)
+	}
+	return nil
+}
+
+// validateImmutableKeys rejects updates that change or remove the value of a key listed in the old spec.immutableKeys.
+func validateImmutableKeys(oldNamespaceLabel, newNamespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	for _, key := range oldNamespaceLabel.Spec.ImmutableKeys {
+		oldValue, ok := oldNamespaceLabel.Spec.Labels[key]
+		if !ok {
+			continue
+		}
+		if newValue := newNamespaceLabel.Spec.Labels[key]; newValue != oldValue {
+			return fmt.Errorf("label %q is immutable and cannot change from %q to %q", key, oldValue, newValue)
+		}
+	}
+	return nil
+}
+
+// validateExemptProtected rejects setting the exempt-protected annotation unless the requesting user belongs to one
+// of the privileged groups. An exemption already present on the old object may be kept by anyone.
+func validateExemptProtected(ctx context.Context, oldNamespaceLabel, newNamespaceLabel *labelsv1alpha1.Namespacelabel) error {
+	if newNamespaceLabel.Annotations[labels.ExemptProtectedAnnotation] != "true" {
+		return nil
+	}
+	if oldNamespaceLabel != nil && oldNamespaceLabel.Annotations[labels.ExemptProtectedAnnotation] == "true" {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot verify who set %s: %w", labels.ExemptProtectedAnnotation, err)
+	}
+
+	privilegedGroups := os.Getenv(ExemptProtectedGroupsEnv)
+	if privilegedGroups == "" {
+		privilegedGroups = DefaultExemptProtectedGroups
+	}
+	for _, group := range strings.Split(privilegedGroups, ",") {
+		if slices.Contains(req.UserInfo.Groups, strings.TrimSpace(group)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("user %q is not allowed to set %s; it requires membership in one of the groups %s",
+		req.UserInfo.Username, labels.ExemptProtectedAnnotation, privilegedGroups)
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -25,9 +26,19 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"os"
+	"strconv"
 	"time"
 
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
 	labelsv1alpha1 "github.com/matanamar10/namespacelabel-operator/api/v1alpha1"
+	"github.com/matanamar10/namespacelabel-operator/internal/denylist"
+	"github.com/matanamar10/namespacelabel-operator/internal/finalizer"
+	"github.com/matanamar10/namespacelabel-operator/internal/labels"
 )
 
 var _ = Describe("Namespacelabel Webhook", func() {
@@ -136,4 +147,785 @@ var _ = Describe("Namespacelabel Webhook", func() {
 			Consistently(getNextEvent, timeout, interval).ShouldNot(ContainSubstring("FailedCreate"))
 		})
 	})
+
+	Context("Template syntax validation", func() {
+		It("should reject template-looking values when templating is disabled", func() {
+			By("Creating a Namespacelabel CR with braces in a value and templating off")
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "templating-off",
+					Namespace: NamespaceName,
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"region": "{{ .Vars.region }}"},
+				},
+			}
+			err := k8sClient.Create(ctx, labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.enableTemplating is false"))
+		})
+
+		It("should admit template-looking values when templating is enabled", func() {
+			const templatingNamespace = "templating-on"
+			createNamespace(templatingNamespace)
+
+			By("Creating a Namespacelabel CR with braces in a value and templating on")
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "templating-on",
+					Namespace: templatingNamespace,
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels:           map[string]string{"region": "{{ .Vars.region }}"},
+					EnableTemplating: true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, labelsCR)).To(Succeed())
+		})
+
+		It("should reject a template that does not parse when templating is enabled", func() {
+			By("Creating a Namespacelabel CR with an unclosed template action")
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "templating-unclosed",
+					Namespace: NamespaceName,
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels:           map[string]string{"region": "{{ .Vars.region"},
+					EnableTemplating: true,
+				},
+			}
+			err := k8sClient.Create(ctx, labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid template"))
+		})
+	})
+
+	Context("Team reference validation", func() {
+		It("should reject a teamRef with an invalid label key", func() {
+			By("Creating a Namespacelabel CR whose teamRef labelKey is not a valid label key")
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "invalid-team-ref",
+					Namespace: NamespaceName,
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"key1": "value1"},
+					TeamRef: &labelsv1alpha1.TeamReference{
+						APIVersion: "teams.example.com/v1",
+						Kind:       "Team",
+						Name:       "platform",
+						LabelKey:   "not a label key",
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid spec.teamRef.labelKey"))
+		})
+
+		It("should reject a teamRef label key with the reserved prefix", func() {
+			By("Creating a Namespacelabel CR whose teamRef labelKey uses the operator's prefix")
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "reserved-team-ref",
+					Namespace: NamespaceName,
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"key1": "value1"},
+					TeamRef: &labelsv1alpha1.TeamReference{
+						APIVersion: "teams.example.com/v1",
+						Kind:       "Team",
+						Name:       "platform",
+						LabelKey:   "labels.dana.io/team",
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("reserved for the operator"))
+		})
+	})
+
+	Context("No-op warnings", func() {
+		var validator *NamespacelabelCustomValidator
+
+		BeforeEach(func() {
+			validator = &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+
+			By("Labelling the namespace directly")
+			namespace := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: NamespaceName}, namespace)).To(Succeed())
+			namespace.Labels = map[string]string{"team": "platform"}
+			Expect(k8sClient.Update(ctx, namespace)).To(Succeed())
+		})
+
+		It("should warn but admit a Namespacelabel whose labels are all already present", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}},
+			}
+			warnings, err := validator.ValidateCreate(ctx, labelsCR)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("is a no-op")))
+		})
+
+		It("should not warn when some label would change the namespace", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform", "app": "web"}},
+			}
+			warnings, err := validator.ValidateCreate(ctx, labelsCR)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		DescribeTable("should compare values according to CASE_INSENSITIVE_VALUES",
+			func(caseInsensitive bool, wantWarning bool) {
+				DeferCleanup(os.Setenv, labels.CaseInsensitiveValuesEnv, os.Getenv(labels.CaseInsensitiveValuesEnv))
+				if caseInsensitive {
+					Expect(os.Setenv(labels.CaseInsensitiveValuesEnv, "true")).To(Succeed())
+				} else {
+					Expect(os.Unsetenv(labels.CaseInsensitiveValuesEnv)).To(Succeed())
+				}
+				labelsCR := &labelsv1alpha1.Namespacelabel{
+					ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+					Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "Platform"}},
+				}
+				warnings, err := validator.ValidateCreate(ctx, labelsCR)
+				Expect(err).NotTo(HaveOccurred())
+				if wantWarning {
+					Expect(warnings).To(ConsistOf(ContainSubstring("is a no-op")))
+				} else {
+					Expect(warnings).To(BeEmpty())
+				}
+			},
+			Entry("a value differing in case is the same value when case-insensitive", true, true),
+			Entry("a value differing in case is a change when case-sensitive", false, false),
+		)
+
+		It("should not warn on update when the labels were applied by the Namespacelabel itself", func() {
+			oldCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}},
+				Status:     labelsv1alpha1.NamespacelabelStatus{AppliedLabels: map[string]string{"team": "platform"}},
+			}
+			newCR := oldCR.DeepCopy()
+			warnings, err := validator.ValidateUpdate(ctx, oldCR, newCR)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("Immutable keys validation", func() {
+		It("should reject an update changing an immutable label", func() {
+			validator := &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+			oldCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels:        map[string]string{"team": "platform"},
+					ImmutableKeys: []string{"team"},
+				},
+			}
+			newCR := oldCR.DeepCopy()
+			newCR.Spec.Labels["team"] = "payments"
+
+			_, err := validator.ValidateUpdate(ctx, oldCR, newCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is immutable"))
+		})
+	})
+
+	Context("Protected label exemption", func() {
+		var (
+			validator *NamespacelabelCustomValidator
+			labelsCR  *labelsv1alpha1.Namespacelabel
+		)
+
+		requestBy := func(username string, groups ...string) context.Context {
+			return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: username, Groups: groups},
+			}})
+		}
+
+		BeforeEach(func() {
+			validator = &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+			labelsCR = &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        NamespaceLabelCR,
+					Namespace:   NamespaceName,
+					Annotations: map[string]string{labels.ExemptProtectedAnnotation: "true"},
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"protected-label": "value"}},
+			}
+		})
+
+		It("should admit the exemption from a privileged user", func() {
+			_, err := validator.ValidateCreate(requestBy("admin", "system:masters"), labelsCR)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject the exemption from an unprivileged user", func() {
+			_, err := validator.ValidateCreate(requestBy("developer", "system:authenticated"), labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is not allowed to set " + labels.ExemptProtectedAnnotation))
+		})
+
+		It("should let an unprivileged user update a CR that is already exempt", func() {
+			newCR := labelsCR.DeepCopy()
+			newCR.Spec.Labels["team"] = "platform"
+			_, err := validator.ValidateUpdate(requestBy("developer", "system:authenticated"), labelsCR, newCR)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Transform validation", func() {
+		It("should reject an unknown transform name", func() {
+			By("Creating a Namespacelabel CR with an unknown transform")
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "invalid-transform",
+					Namespace: NamespaceName,
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels:     map[string]string{"key1": "value1"},
+					Transforms: map[string]labelsv1alpha1.LabelTransform{"key1": "reverse"},
+				},
+			}
+			err := k8sClient.Create(ctx, labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid spec.transforms entry for "key1"`))
+		})
+	})
+
+	Context("Value pattern validation", func() {
+		It("should reject a value pattern that is not a valid regular expression", func() {
+			By("Creating a Namespacelabel CR with an unparsable value pattern")
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "invalid-value-pattern",
+					Namespace: NamespaceName,
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels:        map[string]string{"cost-center": "cc-1234"},
+					ValuePatterns: map[string]string{"cost-center": "cc-[0-9"},
+				},
+			}
+			err := k8sClient.Create(ctx, labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid spec.valuePatterns entry for "cost-center"`))
+		})
+	})
+
+	Context("Maintenance window validation", func() {
+		It("should reject a maintenance window with an invalid day", func() {
+			By("Creating a Namespacelabel CR whose maintenance window names an unknown day")
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "invalid-maintenance-window",
+					Namespace: NamespaceName,
+				},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels: map[string]string{"key1": "value1"},
+					MaintenanceWindow: &labelsv1alpha1.MaintenanceWindow{
+						Start: "09:00",
+						End:   "17:00",
+						Days:  []string{"Funday"},
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid spec.maintenanceWindow: invalid day "Funday"`))
+		})
+	})
+
+	Context("Deny ConfigMap", func() {
+		It("should reject a Namespacelabel targeting a denied namespace", func() {
+			DeferCleanup(os.Setenv, denylist.ConfigMapEnv, os.Getenv(denylist.ConfigMapEnv))
+			Expect(os.Setenv(denylist.ConfigMapEnv, "default/deny-namespaces")).To(Succeed())
+
+			By("Creating the deny ConfigMap listing the namespace")
+			denyList := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "deny-namespaces", Namespace: "default"},
+				Data:       map[string]string{denylist.NamespacesKey: NamespaceName},
+			}
+			Expect(k8sClient.Create(ctx, denyList)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, denyList)).To(Succeed()) })
+
+			validator := &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"key1": "value1"}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, labelsCR)
+			Expect(err).To(MatchError(ContainSubstring("is listed in the operator's deny ConfigMap")))
+
+			By("Targeting a namespace that is not denied")
+			labelsCR.Spec.NamespaceName = "other-namespace"
+			adminCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: "admin", Groups: []string{"system:masters"}},
+			}})
+			_, err = validator.ValidateUpdate(adminCtx, &labelsv1alpha1.Namespacelabel{}, labelsCR)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should release the finalizer of a Namespacelabel whose namespace was denied after it was created", func() {
+			DeferCleanup(os.Setenv, denylist.ConfigMapEnv, os.Getenv(denylist.ConfigMapEnv))
+			Expect(os.Setenv(denylist.ConfigMapEnv, "default/deny-namespaces")).To(Succeed())
+
+			By("Creating the Namespacelabel CR with the controller's finalizer")
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"key1": "value1"}},
+			}
+			Expect(k8sClient.Create(ctx, labelsCR)).To(Succeed())
+			Expect(finalizer.Ensure(ctx, k8sClient, labelsCR, namespacelabellog)).To(Succeed())
+
+			By("Denying the namespace and deleting the Namespacelabel CR")
+			denyList := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "deny-namespaces", Namespace: "default"},
+				Data:       map[string]string{denylist.NamespacesKey: NamespaceName},
+			}
+			Expect(k8sClient.Create(ctx, denyList)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, denyList)).To(Succeed()) })
+			Expect(k8sClient.Delete(ctx, labelsCR)).To(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR)).To(Succeed())
+
+			By("Removing the finalizer the way the controller does")
+			Expect(finalizer.Remove(ctx, k8sClient, labelsCR, namespacelabellog)).To(Succeed())
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(labelsCR), labelsCR))
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
+	Context("Label key convention", func() {
+		DescribeTable("should enforce the configured convention",
+			func(convention, key, expectedErr string) {
+				DeferCleanup(os.Setenv, LabelKeyConventionEnv, os.Getenv(LabelKeyConventionEnv))
+				Expect(os.Setenv(LabelKeyConventionEnv, convention)).To(Succeed())
+
+				validator := &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+				labelsCR := &labelsv1alpha1.Namespacelabel{
+					ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+					Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{key: "platform"}},
+				}
+
+				_, err := validator.ValidateUpdate(ctx, &labelsv1alpha1.Namespacelabel{}, labelsCR)
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				}
+			},
+			Entry("prefixed admits a prefixed key", KeyConventionPrefixed, "example.com/team", ""),
+			Entry("prefixed admits a plain key", KeyConventionPrefixed, "team", ""),
+			Entry("prefixed rejects a dotted key without prefix", KeyConventionPrefixed, "team.example.com",
+				`put the domain in the prefix, as in "example.com/team"`),
+			Entry("flat admits a dotted key", KeyConventionFlat, "team.example.com", ""),
+			Entry("flat rejects a prefixed key", KeyConventionFlat, "example.com/team", `has a prefix, but LABEL_KEY_CONVENTION is "flat"; use "team"`),
+			Entry("an unknown convention skips the check", "camel", "team.example.com", ""),
+		)
+	})
+
+	Context("Startup configuration", func() {
+		DescribeTable("should report the first invalid setting",
+			func(env, value, expectedErr string) {
+				DeferCleanup(os.Setenv, env, os.Getenv(env))
+				Expect(os.Setenv(env, value)).To(Succeed())
+
+				err := ValidateConfig()
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				}
+			},
+			Entry("accepts a known key convention", LabelKeyConventionEnv, KeyConventionFlat, ""),
+			Entry("rejects an unknown key convention", LabelKeyConventionEnv, "camel", `LABEL_KEY_CONVENTION is "camel"`),
+			Entry("accepts a cap", MaxTotalNamespacelabelsEnv, "10", ""),
+			Entry("rejects a negative cap", MaxTotalNamespacelabelsEnv, "-1", `MAX_TOTAL_NAMESPACELABELS is "-1"`),
+			Entry("rejects a cap that is not a number", MaxTotalNamespacelabelsEnv, "ten", "use a non-negative integer"),
+			Entry("accepts a known value enum mode", ValueEnumModeEnv, ValueEnumModeReject, ""),
+			Entry("rejects an unknown value enum mode", ValueEnumModeEnv, "warn", `VALUE_ENUM_MODE is "warn"`),
+		)
+	})
+
+	Context("Cluster-wide Namespacelabel cap", func() {
+		It("should admit creates below the cap and reject them at the cap, counting every namespace", func() {
+			const capNamespace = "cap-existing"
+			createNamespace(capNamespace)
+
+			By("Creating a Namespacelabel in another namespace")
+			existing := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "cap-existing", Namespace: capNamespace},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}},
+			}
+			Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, existing)
+
+			allNamespaceLabels := &labelsv1alpha1.NamespacelabelList{}
+			Expect(k8sClient.List(ctx, allNamespaceLabels)).To(Succeed())
+			total := len(allNamespaceLabels.Items)
+
+			validator := &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: "cap-new"},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}},
+			}
+			DeferCleanup(os.Setenv, MaxTotalNamespacelabelsEnv, os.Getenv(MaxTotalNamespacelabelsEnv))
+
+			By("Admitting a create that reaches the cap")
+			Expect(os.Setenv(MaxTotalNamespacelabelsEnv, strconv.Itoa(total+1))).To(Succeed())
+			_, err := validator.ValidateCreate(ctx, labelsCR)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Rejecting a create over the cap")
+			Expect(os.Setenv(MaxTotalNamespacelabelsEnv, strconv.Itoa(total))).To(Succeed())
+			_, err = validator.ValidateCreate(ctx, labelsCR)
+			Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("the cluster already has %d NamespaceLabels", total))))
+			Expect(getNextEvent()).To(ContainSubstring("FailedCreate"))
+
+			By("Skipping the cap when it is not a number")
+			Expect(os.Setenv(MaxTotalNamespacelabelsEnv, "ten")).To(Succeed())
+			_, err = validator.ValidateCreate(ctx, labelsCR)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Repeated skip warnings", func() {
+		var (
+			validator *NamespacelabelCustomValidator
+			oldCR     *labelsv1alpha1.Namespacelabel
+		)
+
+		BeforeEach(func() {
+			validator = &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+			oldCR = &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{
+					"protected-label": "value",
+					"owner":           "platform",
+					"app":             "web",
+				}},
+				Status: labelsv1alpha1.NamespacelabelStatus{
+					AppliedLabels: map[string]string{"app": "web"},
+					SkippedLabels: map[string]string{"protected-label": "value"},
+					SkipReasons:   map[string]labelsv1alpha1.SkipReason{"protected-label": labelsv1alpha1.SkipReasonProtected},
+					Conditions: []metav1.Condition{{
+						Type:               string(labelsv1alpha1.ConditionDuplicateLabels),
+						Status:             metav1.ConditionTrue,
+						Reason:             string(labelsv1alpha1.ReasonDuplicateLabelsHandled),
+						LastTransitionTime: metav1.Now(),
+					}},
+				},
+			}
+		})
+
+		It("should warn about protected and duplicate labels skipped on the last apply", func() {
+			newCR := oldCR.DeepCopy()
+			newCR.Spec.Labels["env"] = "prod"
+			warnings, err := validator.ValidateUpdate(ctx, oldCR, newCR)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				And(ContainSubstring("label owner"), ContainSubstring("duplicate")),
+				And(ContainSubstring("label protected-label"), ContainSubstring(string(labelsv1alpha1.SkipReasonProtected))),
+			))
+		})
+
+		It("should not warn about skipped labels that were removed or given a new value", func() {
+			newCR := oldCR.DeepCopy()
+			newCR.Spec.Labels = map[string]string{"protected-label": "other", "app": "web"}
+			warnings, err := validator.ValidateUpdate(ctx, oldCR, newCR)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("Missing namespace validation", func() {
+		It("should reject a Namespacelabel without metadata.namespace", func() {
+			validator := &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"team": "platform"}},
+			}
+			_, err := validator.ValidateCreate(ctx, labelsCR)
+			Expect(err).To(MatchError(ContainSubstring("metadata.namespace must be set")))
+		})
+	})
+
+	Context("Reserved prefix validation", func() {
+		It("should reject label keys under the operator's reserved prefix", func() {
+			specHashKey := labels.SpecHashLabelKey(NamespaceName, NamespaceLabelCR)
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{
+					"team":                   "platform",
+					specHashKey:              "0123456789",
+					"labels.dana.io/managed": "true",
+				}},
+			}
+			err := k8sClient.Create(ctx, labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("label keys labels.dana.io/managed, " + specHashKey + " use the prefix"))
+		})
+
+		It("should reject an alias key under the reserved prefix", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels:  map[string]string{"team": "platform"},
+					Aliases: map[string][]string{"team": {"labels.dana.io/team"}},
+				},
+			}
+			err := k8sClient.Create(ctx, labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("reserved for the operator"))
+		})
+
+		It("should admit keys under other prefixes", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Labels: map[string]string{"dana.io/team": "platform"}},
+			}
+			Expect(k8sClient.Create(ctx, labelsCR)).To(Succeed())
+		})
+	})
+
+	Context("Annotation validation", func() {
+		It("should reject an invalid annotation key", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Annotations: map[string]string{"not a key": "value"}},
+			}
+			err := k8sClient.Create(ctx, labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid spec.annotations key "not a key"`))
+		})
+
+		It("should reject annotation keys under the operator's reserved prefix", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{Annotations: map[string]string{
+					"openshift.io/description":   "Platform team",
+					labels.LastAppliedAnnotation: "2024-01-01T00:00:00Z",
+				}},
+			}
+			err := k8sClient.Create(ctx, labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("annotation keys labels.dana.io/last-applied use the prefix"))
+		})
+
+		It("should admit valid annotation keys", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec:       labelsv1alpha1.NamespacelabelSpec{Annotations: map[string]string{"openshift.io/description": "Platform team"}},
+			}
+			Expect(k8sClient.Create(ctx, labelsCR)).To(Succeed())
+		})
+	})
+
+	Context("Overwrite policy validation", func() {
+		It("should reject an unknown overwrite policy", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels:          map[string]string{"team": "platform"},
+					OverwritePolicy: "Replace",
+				},
+			}
+			err := k8sClient.Create(ctx, labelsCR)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid spec.overwritePolicy "Replace": must be Skip or Overwrite`))
+		})
+
+		It("should admit the Overwrite policy", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels:          map[string]string{"team": "platform"},
+					OverwritePolicy: labelsv1alpha1.OverwritePolicyOverwrite,
+				},
+			}
+			Expect(k8sClient.Create(ctx, labelsCR)).To(Succeed())
+		})
+	})
+
+	Context("Value enum validation", func() {
+		DescribeTable("should enforce spec.valueEnums according to the configured mode",
+			func(mode, value, expectedErr string) {
+				DeferCleanup(os.Setenv, ValueEnumModeEnv, os.Getenv(ValueEnumModeEnv))
+				Expect(os.Setenv(ValueEnumModeEnv, mode)).To(Succeed())
+
+				validator := &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+				labelsCR := &labelsv1alpha1.Namespacelabel{
+					ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+					Spec: labelsv1alpha1.NamespacelabelSpec{
+						Labels:     map[string]string{"env": value},
+						ValueEnums: map[string][]string{"env": {"dev", "prod"}},
+					},
+				}
+
+				_, err := validator.ValidateUpdate(ctx, &labelsv1alpha1.Namespacelabel{}, labelsCR)
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				}
+			},
+			Entry("reject admits an allowed value", ValueEnumModeReject, "prod", ""),
+			Entry("reject rejects a disallowed value", ValueEnumModeReject, "staging", `spec.valueEnums[env]: Invalid value: "staging": label "env": value "staging" is not one of dev, prod`),
+			Entry("skip admits a disallowed value", ValueEnumModeSkip, "staging", ""),
+			Entry("an unset mode admits a disallowed value", "", "staging", ""),
+			Entry("an unknown mode skips the check", "warn", "staging", ""),
+		)
+
+		It("should reject an entry listing no values", func() {
+			validator := &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					Labels:     map[string]string{"env": "prod"},
+					ValueEnums: map[string][]string{"env": {}},
+				},
+			}
+
+			_, err := validator.ValidateUpdate(ctx, &labelsv1alpha1.Namespacelabel{}, labelsCR)
+			Expect(err).To(MatchError(ContainSubstring(`spec.valueEnums[env]: Required value: the entry lists no values`)))
+		})
+	})
+
+	Context("Target namespace access", func() {
+		var validator *NamespacelabelCustomValidator
+
+		requestBy := func(username string, groups ...string) context.Context {
+			return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: username, Groups: groups},
+			}})
+		}
+
+		BeforeEach(func() {
+			validator = &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+		})
+
+		It("should reject a user who may not patch the namespace named in spec.namespaceName", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					NamespaceName: "kube-system",
+					Labels:        map[string]string{"team": "platform"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(requestBy("developer", "system:authenticated"), labelsCR)
+			Expect(err).To(MatchError(ContainSubstring(`user "developer" may not patch namespace kube-system`)))
+
+			By("Pointing an existing Namespacelabel at the namespace")
+			oldCR := labelsCR.DeepCopy()
+			oldCR.Spec.NamespaceName = ""
+			_, err = validator.ValidateUpdate(requestBy("developer", "system:authenticated"), oldCR, labelsCR)
+			Expect(err).To(MatchError(ContainSubstring("may not patch namespace kube-system")))
+		})
+
+		It("should reject a user who may not patch every namespace a selector can match", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					NamespaceLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "prod"}},
+					Labels:                 map[string]string{"team": "platform"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(requestBy("developer", "system:authenticated"), labelsCR)
+			Expect(err).To(MatchError(ContainSubstring("which spec.namespaceLabelSelector requires")))
+		})
+
+		It("should admit a user who may patch the target namespace", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					NamespaceName: "kube-system",
+					Labels:        map[string]string{"team": "platform"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(requestBy("admin", "system:masters"), labelsCR)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not check access when the Namespacelabel labels its own namespace", func() {
+			labelsCR := &labelsv1alpha1.Namespacelabel{
+				ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+				Spec: labelsv1alpha1.NamespacelabelSpec{
+					NamespaceName: NamespaceName,
+					Labels:        map[string]string{"team": "platform"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(requestBy("developer", "system:authenticated"), labelsCR)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Required resource access", func() {
+		DescribeTable("should only admit a spec.requires the requesting user may get",
+			func(username, group, namespace, expectedErr string) {
+				validator := &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+				labelsCR := &labelsv1alpha1.Namespacelabel{
+					ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+					Spec: labelsv1alpha1.NamespacelabelSpec{
+						Labels: map[string]string{"team": "platform"},
+						Requires: &labelsv1alpha1.ResourceReference{
+							APIVersion: "v1", Kind: "Secret", Name: "db-credentials", Namespace: namespace,
+						},
+					},
+				}
+				requestCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+					UserInfo: authenticationv1.UserInfo{Username: username, Groups: []string{group}},
+				}})
+
+				_, err := validator.ValidateCreate(requestCtx, labelsCR)
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				}
+			},
+			Entry("rejects a resource in another namespace", "developer", "system:authenticated", "kube-system",
+				`user "developer" may not get Secret db-credentials, which spec.requires names`),
+			Entry("rejects a resource in the CR's own namespace the user may not read", "developer", "system:authenticated", "",
+				"may not get Secret db-credentials"),
+			Entry("admits a user who may get the resource", "admin", "system:masters", "kube-system", ""),
+		)
+	})
+
+	Context("Propagation targets", func() {
+		DescribeTable("should only admit the propagation targets the operator may patch",
+			func(target, expectedErr string) {
+				validator := &NamespacelabelCustomValidator{Client: k8sClient, Logger: namespacelabellog, Recorder: recorder}
+				labelsCR := &labelsv1alpha1.Namespacelabel{
+					ObjectMeta: metav1.ObjectMeta{Name: NamespaceLabelCR, Namespace: NamespaceName},
+					Spec: labelsv1alpha1.NamespacelabelSpec{
+						Labels:      map[string]string{"team": "platform"},
+						PropagateTo: []string{target},
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, labelsCR)
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				}
+			},
+			Entry("admits ConfigMaps", "v1/configmaps", ""),
+			Entry("admits Deployments", "apps/v1/deployments", ""),
+			Entry("rejects namespaces", "v1/namespaces", `unsupported propagation target "v1/namespaces"`),
+			Entry("rejects cluster roles", "rbac.authorization.k8s.io/v1/clusterroles", "unsupported propagation target"),
+			Entry("rejects a namespaced resource the operator may not patch", "v1/secrets",
+				"supported resources are configmaps, serviceaccounts, services, daemonsets.apps, deployments.apps, statefulsets.apps"),
+		)
+	})
 })
@@ -0,0 +1,110 @@
+package window
+
+import (
+	"fmt"
+	"time"
+
+	labelsv1alpha1 "github.com/matanamar10/namespacelabel-operator/api/v1alpha1"
+)
+
+// timeOfDayLayout is the layout of a window's start and end times.
+const timeOfDayLayout = "15:04"
+
+// weekdays maps the day names accepted in a window's days to their weekday.
+var weekdays = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+// Window is a parsed spec.maintenanceWindow.
+type Window struct {
+	// start and end are the offsets from midnight at which the window opens and closes.
+	start, end time.Duration
+	// days holds the weekdays the window opens on; nil means every day.
+	days     map[time.Weekday]bool
+	location *time.Location
+}
+
+// Parse validates a maintenance window and returns it in a form that can be checked against a time.
+func Parse(spec *labelsv1alpha1.MaintenanceWindow) (*Window, error) {
+	start, err := parseTimeOfDay(spec.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start: %w", err)
+	}
+	end, err := parseTimeOfDay(spec.End)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end: %w", err)
+	}
+	if start == end {
+		return nil, fmt.Errorf("start and end are both %s; the window would be empty", spec.Start)
+	}
+
+	window := &Window{start: start, end: end, location: time.UTC}
+	if spec.TimeZone != "" {
+		if window.location, err = time.LoadLocation(spec.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid timeZone %q: %w", spec.TimeZone, err)
+		}
+	}
+	if len(spec.Days) > 0 {
+		window.days = make(map[time.Weekday]bool, len(spec.Days))
+		for _, day := range spec.Days {
+			weekday, ok := weekdays[day]
+			if !ok {
+				return nil, fmt.Errorf("invalid day %q; use Mon, Tue, Wed, Thu, Fri, Sat or Sun", day)
+			}
+			window.days[weekday] = true
+		}
+	}
+	return window, nil
+}
+
+// parseTimeOfDay returns the offset from midnight of an "HH:MM" time.
+func parseTimeOfDay(value string) (time.Duration, error) {
+	parsed, err := time.Parse(timeOfDayLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an HH:MM time", value)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+// Contains reports whether t falls inside the window.
+func (w *Window) Contains(t time.Time) bool {
+	t = t.In(w.location)
+	offset := t.Sub(midnight(t))
+
+	if w.start < w.end {
+		return w.opensOn(t.Weekday()) && offset >= w.start && offset < w.end
+	}
+	// The window runs past midnight: it is open late on the day it opens and early on the day after.
+	if w.opensOn(t.Weekday()) && offset >= w.start {
+		return true
+	}
+	return w.opensOn(t.AddDate(0, 0, -1).Weekday()) && offset < w.end
+}
+
+// NextOpen returns the first time after t at which the window opens.
+func (w *Window) NextOpen(t time.Time) time.Time {
+	t = t.In(w.location)
+	for days := 0; days <= 7; days++ {
+		open := midnight(t.AddDate(0, 0, days)).Add(w.start)
+		if open.After(t) && w.opensOn(open.Weekday()) {
+			return open
+		}
+	}
+	return t
+}
+
+func (w *Window) opensOn(day time.Weekday) bool {
+	return w.days == nil || w.days[day]
+}
+
+// midnight returns the start of t's day in t's location.
+func midnight(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}